@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type auditOpts struct {
+	*serverOpts
+	since string
+}
+
+func newAuditOpts() *auditOpts {
+	return &auditOpts{
+		serverOpts: newServerOpts(),
+		since:      "24h",
+	}
+}
+
+func auditCmd() *cobra.Command {
+	opts := newAuditOpts()
+
+	cmd := &cobra.Command{
+		Use:          "audit",
+		Short:        "Show the audit log of API calls",
+		Long:         `Show who called which API of the daemon, for the server given by --path, or for all servers when --path is not set`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *auditOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.since, "since", o.since, "Show entries since a duration ago (like 2h) or an RFC 3339 time")
+}
+
+func (o *auditOpts) Since() (time.Time, error) {
+	d, err := time.ParseDuration(o.since)
+	if err == nil {
+		return time.Now().Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, o.since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q: expected a duration or an RFC 3339 time", o.since)
+	}
+
+	return t, nil
+}
+
+func (o *auditOpts) Run(cmd *cobra.Command, args []string) error {
+	since, err := o.Since()
+	if err != nil {
+		return err
+	}
+
+	var path string
+	if cmd.Flags().Changed("path") {
+		path, err = o.Path()
+		if err != nil {
+			return err
+		}
+	}
+
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	stream, err := c.Audit(cmd.Context(), &svctl.AuditOpts{
+		Since: timestamppb.New(since),
+		Path:  path,
+	})
+	if err != nil {
+		return rpcError("Audit", err)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TIME\tCALLER\tRPC\tPATH\tRESULT\tERROR")
+
+	for {
+		e, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("error receiving audit log: %v", err)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.GetTime().AsTime().Local().Format(time.RFC3339),
+			e.GetCaller(),
+			e.GetRpc(),
+			e.GetPath(),
+			e.GetCode(),
+			e.GetError(),
+		)
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd())
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type autostartOpts struct {
+	*serverOpts
+	disable bool
+}
+
+func newAutostartOpts() *autostartOpts {
+	return &autostartOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func autostartCmd() *cobra.Command {
+	opts := newAutostartOpts()
+
+	cmd := &cobra.Command{
+		Use:          "autostart",
+		Short:        "Start the server whenever the daemon boots",
+		Long:         `Start the server whenever the daemon boots, even if it was stopped before. Without autostart a server is only started on boot if it was running.`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *autostartOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.disable, "disable", false, "Only start the server on boot if it was running")
+}
+
+func (o *autostartOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second)
+	defer cancel()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	r, err := c.SetAutostart(ctx, &svctl.AutostartOpts{
+		Path:    path,
+		Enabled: !o.disable,
+	})
+	if err != nil {
+		return rpcError("SetAutostart", err)
+	}
+
+	if r.GetAutostart() {
+		cmd.Println("Autostart enabled")
+	} else {
+		cmd.Println("Autostart disabled")
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(autostartCmd())
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type cleanupOpts struct {
+	*serverOpts
+	archive bool
+	force   bool
+}
+
+func newCleanupOpts() *cleanupOpts {
+	return &cleanupOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func cleanupCmd() *cobra.Command {
+	opts := newCleanupOpts()
+
+	cmd := &cobra.Command{
+		Use:          "cleanup",
+		Short:        "Unregister the server and remove svctl dir (.svctl)",
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *cleanupOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.archive, "archive", false, "Keep svctl dir renamed with a timestamp suffix instead of removing it")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Remove svctl dir even if the server could not be unregistered")
+}
+
+func (o *cleanupOpts) Run(cmd *cobra.Command, args []string) error {
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	svctlPath, err := o.SvctlPath()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(svctlPath); err != nil {
+		return errors.New("svctl was not initialized on this path")
+	}
+
+	err = o.unregister(cmd, path)
+	if err != nil {
+		if !o.force {
+			return fmt.Errorf("%w - use --force to clean up anyway", err)
+		}
+		cmd.PrintErrf("Warning: %v\n", err)
+	}
+
+	if o.archive {
+		archivePath := fmt.Sprintf("%s-%s", svctlPath, time.Now().Format("20060102-150405"))
+		err = os.Rename(svctlPath, archivePath)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Svctl dir archived to %s\n", archivePath)
+		return nil
+	}
+
+	err = os.RemoveAll(svctlPath)
+	if err != nil {
+		return err
+	}
+
+	cmd.Println("Svctl dir removed")
+	return nil
+}
+
+func (o *cleanupOpts) unregister(cmd *cobra.Command, path string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), o.unregisterTimeout())
+	defer cancel()
+
+	r, err := c.Unregister(ctx, &svctl.ServerOpts{Path: path})
+	if status.Code(err) == codes.NotFound {
+		// Nothing to unregister, the svctl dir can go anyway
+		return nil
+	}
+	if err != nil {
+		return rpcError("Unregister", err)
+	}
+
+	cmd.Printf("Server status: %v\n", r.GetStatus().String())
+	return nil
+}
+
+// unregisterTimeout leaves the daemon time to stop the server gracefully and
+// to kill it after the stop timeout.
+func (o *cleanupOpts) unregisterTimeout() time.Duration {
+	timeout := prbf2proc.DefaultStopTimeout
+
+	sv, err := o.Server()
+	if err == nil {
+		config := sv.Settings.Config()
+		if config.Stop != nil && config.Stop.Timeout > 0 {
+			timeout = config.Stop.Timeout
+		}
+	}
+
+	return timeout + prbf2proc.KillTimeout + 5*time.Second
+}
+
+func init() {
+	rootCmd.AddCommand(cleanupCmd())
+}
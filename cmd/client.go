@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sboon-gg/svctl/internal/auth"
+	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/pflag"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	daemonEnv = "SVCTL_DAEMON"
+	tokenEnv  = "SVCTL_TOKEN"
+)
+
+// clientOpts are the global flags for reaching the daemon.
+type clientOpts struct {
+	daemonAddr string
+	tls        bool
+	tlsCA      string
+	tlsCert    string
+	tlsKey     string
+	token      string
+}
+
+var clientFlags = &clientOpts{}
+
+func (o *clientOpts) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&o.daemonAddr, "daemon", "", "Daemon endpoint, tcp://host:port or unix:///path.sock (default $"+daemonEnv+" or the daemon config)")
+	flags.BoolVar(&o.tls, "tls", false, "Connect to the daemon with TLS, implied by the other --tls flags")
+	flags.StringVar(&o.tlsCA, "tls-ca", "", "CA certificate to verify the daemon with (default system roots)")
+	flags.StringVar(&o.tlsCert, "tls-cert", "", "Client certificate for mutual TLS")
+	flags.StringVar(&o.tlsKey, "tls-key", "", "Client key for mutual TLS")
+	flags.StringVar(&o.token, "token", "", "Bearer token to authenticate with (default $"+tokenEnv+")")
+}
+
+// endpoint returns the endpoint of the daemon to talk to, taken from
+// --daemon, $SVCTL_DAEMON or the local daemon config, in that order.
+func (o *clientOpts) endpoint() (daemon.Endpoint, error) {
+	addr := o.daemonAddr
+	if addr == "" {
+		addr = os.Getenv(daemonEnv)
+	}
+
+	if addr == "" {
+		path, err := daemon.DefaultConfigPath()
+		if err != nil {
+			return daemon.Endpoint{}, err
+		}
+
+		config, err := daemon.ReadConfig(path)
+		if err != nil {
+			return daemon.Endpoint{}, err
+		}
+
+		addr = config.Listen
+	}
+
+	return daemon.ParseEndpoint(addr)
+}
+
+func (o *clientOpts) dialOptions() ([]grpc.DialOption, error) {
+	token := o.token
+	if token == "" {
+		token = os.Getenv(tokenEnv)
+	}
+
+	useTLS := o.tls || o.tlsCA != "" || o.tlsCert != "" || o.tlsKey != ""
+
+	if !useTLS {
+		if token != "" {
+			return nil, errors.New("a token can only be sent over TLS, use --tls")
+		}
+
+		return []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, nil
+	}
+
+	tlsConfig, err := auth.ClientTLSConfig(o.tlsCA, o.tlsCert, o.tlsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.TokenCredentials(token)))
+	}
+
+	return opts, nil
+}
+
+func daemonClient() (svctl.ServersClient, *grpc.ClientConn, error) {
+	endpoint, err := clientFlags.endpoint()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts, err := clientFlags.dialOptions()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := grpc.Dial(endpoint.Target(), opts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to gRPC server at %s: %v", endpoint, err)
+	}
+
+	return svctl.NewServersClient(conn), conn, nil
+}
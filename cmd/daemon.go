@@ -1,22 +1,35 @@
 package cmd
 
 import (
-	"fmt"
+	"context"
+	"errors"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"time"
 
+	"github.com/coreos/go-systemd/v22/activation"
 	"github.com/sboon-gg/svctl/internal/api"
+	"github.com/sboon-gg/svctl/internal/audit"
+	"github.com/sboon-gg/svctl/internal/auth"
 	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/sboon-gg/svctl/internal/systemd"
 	"github.com/sboon-gg/svctl/svctl"
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
-const (
-	daemonPort = "50051"
-)
+// grpcStopTimeout is how long running calls get to finish on shutdown.
+const grpcStopTimeout = 5 * time.Second
 
 type daemonOpts struct {
+	configPath string
+	listen     string
+	detach     bool
+	logFile    string
 }
 
 func newDaemonOpts() *daemonOpts {
@@ -27,40 +40,248 @@ func daemonCmd() *cobra.Command {
 	opts := newDaemonOpts()
 
 	cmd := &cobra.Command{
-		Use:  "daemon",
-		RunE: opts.Run,
+		Use:          "daemon",
+		Short:        "Run the daemon supervising registered servers",
+		SilenceUsage: true,
+		RunE:         opts.Run,
 	}
 
+	opts.AddFlags(cmd)
+
+	cmd.AddCommand(genCertsCmd())
+	cmd.AddCommand(genTokenCmd())
+	cmd.AddCommand(installServiceCmd())
+
 	return cmd
 }
 
+func (o *daemonOpts) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.configPath, "config", "", "Path to daemon config file (default is daemon.yaml in the user config directory)")
+	cmd.Flags().StringVar(&o.listen, "listen", "", "Endpoint to listen on, tcp://host:port or unix:///path.sock (default "+daemon.DefaultListen()+")")
+	cmd.Flags().BoolVar(&o.detach, "detach", false, "Run the daemon in the background")
+	cmd.Flags().StringVar(&o.logFile, "log-file", "", "File to send output to with --detach (default is daemon.log in the cache directory)")
+}
+
+func (o *daemonOpts) Config() (*daemon.Config, error) {
+	path := o.configPath
+	if path == "" {
+		var err error
+		path, err = daemon.DefaultConfigPath()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	config, err := daemon.ReadConfig(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.listen != "" {
+		config.Listen = o.listen
+	}
+
+	return config, nil
+}
+
 func (o *daemonOpts) Run(cmd *cobra.Command, args []string) error {
-	d, err := daemon.Recover()
+	config, err := o.Config()
 	if err != nil {
 		return err
 	}
 
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", daemonPort))
+	endpoint, err := daemon.ParseEndpoint(config.Listen)
 	if err != nil {
-		log.Fatalf("failed to listen on port %s: %v", daemonPort, err)
+		return err
+	}
+
+	if o.detach && !takeDetached() {
+		cacheDir, err := daemon.CacheDir(config.CacheDir)
+		if err != nil {
+			return err
+		}
+
+		return detach(cmd, cacheDir, o.logFile, endpoint)
+	}
+
+	if len(ignoredSignals) > 0 {
+		signal.Ignore(ignoredSignals...)
 	}
 
-	s := grpc.NewServer()
+	d, err := daemon.Recover(config.CacheDir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+
+	d.Audit().Configure(config.Audit.Log())
+
+	lis, endpoint, err := listen(config, endpoint)
+	if err != nil {
+		log.Fatalf("failed to listen on %s: %v", endpoint, err)
+	}
+
+	serverOpts, err := grpcServerOptions(config, endpoint, d.Audit())
+	if err != nil {
+		return err
+	}
+
+	s := grpc.NewServer(serverOpts...)
 	svctl.RegisterServersServer(s, api.NewDaemonServer(d))
-	log.Printf("gRPC server listening at %v", lis.Addr())
+	log.Printf("gRPC server listening at %s", endpoint)
 
+	stopped := make(chan struct{})
 	go func() {
+		defer close(stopped)
+
 		<-cmd.Context().Done()
-		s.GracefulStop()
+		log.Printf("Shutting down with policy %s", config.Shutdown.Policy)
+		stopGRPC(s)
 	}()
 
+	go d.Reconcile(cmd.Context(), config.StartDelay)
+	go d.Watch(cmd.Context())
+	go reloadOnSignal(cmd.Context(), d)
+	go systemd.Notify(cmd.Context(), d.Summary, d.Alive)
+
 	if err := s.Serve(lis); err != nil {
 		log.Fatalf("failed to serve: %v", err)
 	}
 
+	<-stopped
+
+	d.Shutdown(config.Shutdown)
+	log.Printf("Daemon stopped")
+
 	return nil
 }
 
+// stopGRPC lets running calls finish, but cuts off those still running after
+// grpcStopTimeout, like followed event streams.
+func stopGRPC(s *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(grpcStopTimeout):
+		s.Stop()
+		<-done
+	}
+}
+
+// reloadOnSignal reloads the settings of all servers whenever one of
+// reloadSignals arrives.
+func reloadOnSignal(ctx context.Context, d *daemon.Daemon) {
+	if len(reloadSignals) == 0 {
+		return
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, reloadSignals...)
+	defer signal.Stop(sig)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sig:
+			log.Printf("Reloading settings of all servers")
+
+			err := d.ReloadAll()
+			if err != nil {
+				log.Printf("Failed to reload settings: %v", err)
+			}
+		}
+	}
+}
+
+// listen uses the socket passed by systemd socket activation, and listens on
+// endpoint when there is none.
+func listen(config *daemon.Config, endpoint daemon.Endpoint) (net.Listener, daemon.Endpoint, error) {
+	listeners, err := activation.Listeners()
+	if err != nil {
+		return nil, endpoint, err
+	}
+
+	if len(listeners) == 0 {
+		lis, err := endpoint.Listen(config.Socket)
+		return lis, endpoint, err
+	}
+
+	if listeners[0] == nil {
+		return nil, endpoint, errors.New("socket passed by systemd is not a stream socket")
+	}
+
+	for _, l := range listeners[1:] {
+		if l != nil {
+			l.Close()
+		}
+	}
+
+	addr := listeners[0].Addr()
+	log.Printf("Using socket %s passed by systemd instead of %s", addr, endpoint)
+
+	return listeners[0], daemon.Endpoint{Network: addr.Network(), Address: addr.String()}, nil
+}
+
+// grpcServerOptions sets up transport security, authentication and
+// authorization. Unix socket callers are identified by their uid, tcp://
+// callers by a client certificate or a token when configured.
+func grpcServerOptions(config *daemon.Config, endpoint daemon.Endpoint, auditLog *audit.Log) ([]grpc.ServerOption, error) {
+	var (
+		creds    credentials.TransportCredentials
+		required bool
+	)
+
+	if endpoint.Network == "unix" {
+		creds = auth.PeerCredentials()
+	} else if config.TLS != nil {
+		tlsConfig, err := auth.ServerTLSConfig(config.TLS.Cert, config.TLS.Key, config.TLS.ClientCA)
+		if err != nil {
+			return nil, err
+		}
+
+		creds = credentials.NewTLS(tlsConfig)
+		required = config.TLS.ClientCA != "" || len(config.Tokens) > 0
+	} else {
+		if len(config.Tokens) > 0 {
+			return nil, errors.New("tokens require tls to be configured")
+		}
+
+		creds = insecure.NewCredentials()
+		log.Printf("Warning: requests over %s are not authenticated", endpoint)
+	}
+
+	authenticator := auth.NewAuthenticator(config.Tokens, required)
+
+	policy, err := auth.NewPolicy(config.Allow.Users, config.Allow.Groups, config.Roles, config.DefaultRole)
+	if err != nil {
+		return nil, err
+	}
+
+	// Local clients can't be identified otherwise, like on the default
+	// tcp://localhost endpoint off Linux
+	if !auth.PeerCredentialsSupported && endpoint.Network != "unix" {
+		policy.TrustLoopback()
+	}
+
+	if endpoint.Network != "unix" && !policy.RemoteOperators() {
+		log.Printf("Warning: callers over %s can only query servers, bind roles to certificates or tokens or set default_role to allow more", endpoint)
+	}
+
+	// Callers are identified first and audited before the policy may reject
+	// them, so denied calls show up in the audit log too
+	return []grpc.ServerOption{
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor, auditLog.UnaryInterceptor, policy.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor, auditLog.StreamInterceptor, policy.StreamInterceptor),
+	}, nil
+}
+
 func init() {
 	rootCmd.AddCommand(daemonCmd())
 }
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// detachedEnv is set for the background daemon so it doesn't detach again
+	detachedEnv = "SVCTL_DETACHED"
+
+	daemonLogFile = "daemon.log"
+
+	// detachTimeout is how long the background daemon gets to start listening
+	detachTimeout = 30 * time.Second
+	// detachPollInterval is how often the endpoint is tried meanwhile
+	detachPollInterval = 100 * time.Millisecond
+)
+
+// takeDetached reports whether this is the background daemon. It removes
+// detachedEnv, so it isn't passed on to the servers.
+func takeDetached() bool {
+	_, ok := os.LookupEnv(detachedEnv)
+	if ok {
+		os.Unsetenv(detachedEnv)
+	}
+
+	return ok
+}
+
+// detach starts the daemon again in the background, with stdout and stderr
+// appended to logPath, and returns once it accepts connections on endpoint.
+func detach(cmd *cobra.Command, cacheDir, logPath string, endpoint daemon.Endpoint) error {
+	// Fail here rather than in the background where nobody notices
+	err := daemon.CheckRunning(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	if logPath == "" {
+		logPath = filepath.Join(cacheDir, daemonLogFile)
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer logFile.Close()
+
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		return err
+	}
+	defer devNull.Close()
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	proc, err := os.StartProcess(exe, os.Args, &os.ProcAttr{
+		Env:   append(os.Environ(), detachedEnv+"=1"),
+		Files: []*os.File{devNull, logFile, logFile},
+		Sys:   detachAttr(),
+	})
+	if err != nil {
+		return err
+	}
+
+	exited := make(chan error, 1)
+	go func() {
+		state, err := proc.Wait()
+		if err == nil {
+			err = fmt.Errorf("daemon %s", state)
+		}
+		exited <- err
+	}()
+
+	err = waitListening(endpoint, exited)
+	if err != nil {
+		return fmt.Errorf("%w, see %s", err, logPath)
+	}
+
+	cmd.Printf("Daemon started with pid %d, logging to %s\n", proc.Pid, logPath)
+
+	return nil
+}
+
+// waitListening waits until endpoint accepts connections, the daemon exits
+// or detachTimeout passes.
+func waitListening(endpoint daemon.Endpoint, exited <-chan error) error {
+	timeout := time.After(detachTimeout)
+
+	ticker := time.NewTicker(detachPollInterval)
+	defer ticker.Stop()
+
+	for {
+		conn, err := net.DialTimeout(endpoint.Network, endpoint.Address, detachPollInterval)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		select {
+		case err := <-exited:
+			return err
+		case <-timeout:
+			return fmt.Errorf("daemon is not listening on %s after %s", endpoint, detachTimeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,13 @@
+//go:build unix
+
+package cmd
+
+import "syscall"
+
+// detachAttr starts the daemon in a new session without a controlling
+// terminal, so closing the terminal doesn't stop it.
+func detachAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		Setsid: true,
+	}
+}
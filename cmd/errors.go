@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commandActions maps FSM actions to the commands issuing them.
+var commandActions = map[string]string{
+	"Start":   "start",
+	"Stop":    "stop",
+	"Restart": "restart",
+	"Reset":   "reset",
+}
+
+// rpcError turns an error returned by the daemon into a message for humans.
+func rpcError(method string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return fmt.Errorf("error calling function %s: %v", method, err)
+	}
+
+	switch st.Code() {
+	case codes.FailedPrecondition:
+		for _, d := range st.Details() {
+			if detail, ok := d.(*svctl.StateDetail); ok {
+				return stateError(detail)
+			}
+		}
+		return errors.New(st.Message())
+	case codes.NotFound, codes.AlreadyExists, codes.InvalidArgument, codes.PermissionDenied, codes.Unauthenticated, codes.Aborted:
+		return errors.New(st.Message())
+	case codes.DeadlineExceeded:
+		return fmt.Errorf("timed out calling %s, the action may still complete - check svctl status", method)
+	case codes.Unavailable:
+		return fmt.Errorf("daemon is not reachable, is it running? (%s)", st.Message())
+	}
+
+	return fmt.Errorf("error calling function %s: %s", method, st.Message())
+}
+
+func stateError(detail *svctl.StateDetail) error {
+	msg := fmt.Sprintf("cannot %s server %s while it is %s",
+		strings.ToLower(detail.GetAction()), detail.GetPath(), detail.GetState())
+
+	var commands []string
+	for _, action := range detail.GetAllowedActions() {
+		if command, ok := commandActions[action]; ok {
+			commands = append(commands, "svctl "+command)
+		}
+	}
+
+	if len(commands) > 0 {
+		msg += fmt.Sprintf(" - try %s", strings.Join(commands, " or "))
+	}
+
+	return errors.New(msg)
+}
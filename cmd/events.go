@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type eventsOpts struct {
+	*serverOpts
+	follow bool
+}
+
+func newEventsOpts() *eventsOpts {
+	return &eventsOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func eventsCmd() *cobra.Command {
+	opts := newEventsOpts()
+
+	cmd := &cobra.Command{
+		Use:          "events",
+		Short:        "Show lifecycle events of servers",
+		Long:         `Show lifecycle events of the server given by --path, or of all servers when --path is not set`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *eventsOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().BoolVarP(&o.follow, "follow", "f", false, "Keep streaming new events")
+}
+
+func (o *eventsOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var path string
+	if cmd.Flags().Changed("path") {
+		path, err = o.Path()
+		if err != nil {
+			return err
+		}
+	}
+
+	stream, err := c.WatchEvents(cmd.Context(), &svctl.WatchEventsOpts{
+		Path:   path,
+		Follow: o.follow,
+	})
+	if err != nil {
+		return rpcError("WatchEvents", err)
+	}
+
+	for {
+		e, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			if cmd.Context().Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("error receiving events: %v", err)
+		}
+
+		fmt.Fprintln(cmd.OutOrStdout(), formatEvent(e))
+	}
+}
+
+func formatEvent(e *svctl.Event) string {
+	parts := []string{
+		e.GetTime().AsTime().Local().Format(time.RFC3339),
+		e.GetPath(),
+		e.GetType(),
+	}
+
+	if e.GetFrom() != "" || e.GetTo() != "" {
+		parts = append(parts, fmt.Sprintf("%s -> %s", e.GetFrom(), e.GetTo()))
+	}
+
+	if e.GetPid() > 0 {
+		parts = append(parts, fmt.Sprintf("pid=%d", e.GetPid()))
+	}
+
+	if e.GetReason() != "" {
+		parts = append(parts, fmt.Sprintf("reason=%q", e.GetReason()))
+	}
+
+	if e.GetError() != "" {
+		parts = append(parts, fmt.Sprintf("error=%q", e.GetError()))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func init() {
+	rootCmd.AddCommand(eventsCmd())
+}
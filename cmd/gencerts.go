@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/sboon-gg/svctl/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+type genCertsOpts struct {
+	out     string
+	hosts   []string
+	clients []string
+}
+
+func newGenCertsOpts() *genCertsOpts {
+	hostname, _ := os.Hostname()
+
+	hosts := []string{"localhost", "127.0.0.1"}
+	if hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+
+	return &genCertsOpts{
+		out:     "certs",
+		hosts:   hosts,
+		clients: []string{"admin"},
+	}
+}
+
+func genCertsCmd() *cobra.Command {
+	opts := newGenCertsOpts()
+
+	cmd := &cobra.Command{
+		Use:   "gen-certs",
+		Short: "Generate a self-signed CA with server and client certificates",
+		Long: `Generate a self-signed CA with server and client certificates for small setups.
+Point tls.cert, tls.key and tls.client_ca of the daemon config at server.crt, server.key and ca.crt,
+and give each client ca.crt and its own certificate.`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *genCertsOpts) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.out, "out", "o", o.out, "Directory to write certificates to")
+	cmd.Flags().StringSliceVar(&o.hosts, "host", o.hosts, "DNS names and IP addresses the daemon is reached at")
+	cmd.Flags().StringSliceVar(&o.clients, "client", o.clients, "Names of client certificates to generate")
+}
+
+func (o *genCertsOpts) Run(cmd *cobra.Command, args []string) error {
+	err := os.MkdirAll(o.out, 0700)
+	if err != nil {
+		return err
+	}
+
+	ca, err := auth.NewCA("svctl CA")
+	if err != nil {
+		return err
+	}
+
+	err = ca.WriteFiles(o.out, "ca")
+	if err != nil {
+		return err
+	}
+
+	err = ca.IssueServer(o.out, "server", o.hosts)
+	if err != nil {
+		return err
+	}
+
+	for _, client := range o.clients {
+		err = ca.IssueClient(o.out, client)
+		if err != nil {
+			return err
+		}
+	}
+
+	cmd.Printf("Certificates written to %s\n", filepath.Clean(o.out))
+	return nil
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sboon-gg/svctl/internal/auth"
+	"github.com/spf13/cobra"
+)
+
+func genTokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "gen-token NAME",
+		Short:        "Generate a bearer token for remote clients",
+		Long:         `Generate a bearer token. Add the printed entry to tokens in the daemon config and pass the token to clients with --token or $SVCTL_TOKEN.`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			token, hash, err := auth.GenerateToken()
+			if err != nil {
+				return err
+			}
+
+			entry, err := yaml.Marshal([]auth.Token{{Name: args[0], Hash: hash}})
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Token (shown only once): %s\n\n", token)
+			fmt.Fprintf(out, "Daemon config entry:\ntokens:\n%s", entry)
+
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/sboon-gg/svctl/internal/systemd"
+	"github.com/spf13/cobra"
+)
+
+type installServiceOpts struct {
+	user        bool
+	runAs       string
+	socket      bool
+	socketPath  string
+	socketGroup string
+	configPath  string
+	dir         string
+	force       bool
+}
+
+func newInstallServiceOpts() *installServiceOpts {
+	return &installServiceOpts{}
+}
+
+func installServiceCmd() *cobra.Command {
+	opts := newInstallServiceOpts()
+
+	cmd := &cobra.Command{
+		Use:   "install-service",
+		Short: "Write a systemd unit running the daemon",
+		Long: `Write a systemd unit running the daemon, for the system service manager or with --user for the user one.
+With --socket a socket unit is written as well and systemd opens the daemon socket, starting the daemon on first use.
+The daemon reports readiness and its status to systemd and is restarted when it stops responding.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *installServiceOpts) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.user, "user", false, "Install a user unit instead of a system one")
+	cmd.Flags().StringVar(&o.runAs, "run-as", "", "User running the daemon of a system unit")
+	cmd.Flags().BoolVar(&o.socket, "socket", false, "Install a socket unit for socket activation")
+	cmd.Flags().StringVar(&o.socketPath, "socket-path", "", "Path of the activated socket (default matches the daemon default)")
+	cmd.Flags().StringVar(&o.socketGroup, "socket-group", "", "Group allowed to use the socket of a system unit")
+	cmd.Flags().StringVar(&o.configPath, "config", "", "Daemon config file passed to the daemon")
+	cmd.Flags().StringVar(&o.dir, "dir", "", "Directory to write units to (default is the systemd unit directory of the scope)")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Overwrite existing units")
+}
+
+func (o *installServiceOpts) Run(cmd *cobra.Command, args []string) error {
+	if o.user && (o.runAs != "" || o.socketGroup != "") {
+		return errors.New("--run-as and --socket-group only apply to system units")
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	exe, err = filepath.EvalSymlinks(exe)
+	if err != nil {
+		return err
+	}
+
+	unitOpts := systemd.UnitOptions{
+		Exe:         exe,
+		User:        o.user,
+		RunAs:       o.runAs,
+		SocketGroup: o.socketGroup,
+	}
+
+	if o.configPath != "" {
+		configPath, err := filepath.Abs(o.configPath)
+		if err != nil {
+			return err
+		}
+
+		unitOpts.Args = append(unitOpts.Args, "--config", configPath)
+	}
+
+	if o.socket {
+		unitOpts.Socket = o.socketPath
+		if unitOpts.Socket == "" {
+			unitOpts.Socket = systemd.DefaultSocket(o.user)
+		}
+	}
+
+	units, err := systemd.Units(unitOpts)
+	if err != nil {
+		return err
+	}
+
+	dir := o.dir
+	if dir == "" {
+		dir, err = systemd.UnitDir(o.user)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(units))
+	for name := range units {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+
+		if _, err := os.Stat(path); err == nil && !o.force {
+			return fmt.Errorf("%s already exists - use --force to overwrite it", path)
+		}
+
+		err = os.WriteFile(path, units[name], 0644)
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Wrote %s\n", path)
+	}
+
+	systemctl := "systemctl"
+	if o.user {
+		systemctl += " --user"
+	}
+
+	enable := systemd.ServiceUnit
+	if o.socket {
+		enable = systemd.SocketUnit
+	}
+
+	cmd.Printf("Enable it with: %s daemon-reload && %s enable --now %s\n", systemctl, systemctl, enable)
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
+	"github.com/spf13/cobra"
+)
+
+type launchOpts struct {
+	*serverOpts
+}
+
+func newLaunchOpts() *launchOpts {
+	return &launchOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func launchCmd() *cobra.Command {
+	opts := newLaunchOpts()
+
+	cmd := &cobra.Command{
+		Use:   "launch",
+		Short: "Show how the server is launched",
+		Long: `Show the command line and environment the daemon starts the server with, as set by launch in .svctl/config.yaml.
+Arguments are rendered with the current values. The output is a shell script: the variables set by launch are added to the
+environment the daemon runs in, the inherited ones shown are those of this command, which match the daemon's when both run in the same environment.`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *launchOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+}
+
+func (o *launchOpts) Run(cmd *cobra.Command, args []string) error {
+	sv, err := o.Server()
+	if err != nil {
+		return err
+	}
+
+	procOpts, err := sv.ProcessOptions()
+	if err != nil {
+		return err
+	}
+
+	proc, err := prbf2proc.New(sv.Path, procOpts...)
+	if err != nil {
+		return err
+	}
+
+	argv, env := proc.Command()
+	out := cmd.OutOrStdout()
+
+	added := make(map[string]bool, len(env))
+	for _, kv := range env {
+		name, _, _ := strings.Cut(kv, "=")
+		added[name] = true
+	}
+
+	var inherited []string
+	for _, kv := range os.Environ() {
+		name, _, _ := strings.Cut(kv, "=")
+		if !added[name] {
+			inherited = append(inherited, kv)
+		}
+	}
+
+	fmt.Fprintln(out, "# Inherited environment")
+	printEnv(out, inherited)
+	fmt.Fprintln(out, "# Set for the server")
+	printEnv(out, env)
+
+	quoted := make([]string, len(argv))
+	for i, arg := range argv {
+		quoted[i] = shellQuote(arg)
+	}
+	fmt.Fprintln(out, strings.Join(quoted, " "))
+
+	return nil
+}
+
+func printEnv(out io.Writer, env []string) {
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		fmt.Fprintf(out, "export %s=%s\n", name, shellQuote(value))
+	}
+}
+
+// shellQuote quotes word for a POSIX shell when needed.
+func shellQuote(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t\n\"'\\$`;&|<>()*?[]{}~#!") {
+		return word
+	}
+
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+func init() {
+	rootCmd.AddCommand(launchCmd())
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type listOpts struct {
+	*watchOpts
+}
+
+func newListOpts() *listOpts {
+	return &listOpts{
+		watchOpts: newWatchOpts(),
+	}
+}
+
+func listCmd() *cobra.Command {
+	opts := newListOpts()
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List all servers registered in the daemon",
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *listOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return o.watchOpts.Run(cmd, func(ctx context.Context, out io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		r, err := c.ListServers(ctx, &svctl.ListServersOpts{})
+		if err != nil {
+			return rpcError("ListServers", err)
+		}
+
+		return printServers(out, r.GetServers()...)
+	})
+}
+
+func init() {
+	rootCmd.AddCommand(listCmd())
+}
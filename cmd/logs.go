@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type logsOpts struct {
+	*serverOpts
+	lines uint32
+}
+
+func newLogsOpts() *logsOpts {
+	return &logsOpts{
+		serverOpts: newServerOpts(),
+		lines:      100,
+	}
+}
+
+func logsCmd() *cobra.Command {
+	opts := newLogsOpts()
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show the latest output of the server",
+		Long: `Show the latest lines the server printed, as kept in memory by the daemon.
+The full output is in .svctl/logs of the server.`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *logsOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().Uint32VarP(&o.lines, "lines", "n", o.lines, "Number of lines to show, 0 for all kept lines")
+}
+
+func (o *logsOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second)
+	defer cancel()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	r, err := c.GetOutput(ctx, &svctl.OutputOpts{
+		Path:  path,
+		Lines: o.lines,
+	})
+	if err != nil {
+		return rpcError("GetOutput", err)
+	}
+
+	out := cmd.OutOrStdout()
+	for _, line := range r.GetLines() {
+		fmt.Fprintln(out, line)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(logsCmd())
+}
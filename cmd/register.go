@@ -5,13 +5,10 @@ package cmd
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/sboon-gg/svctl/svctl"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type registerOpts struct {
@@ -43,12 +40,11 @@ func (o *registerOpts) AddFlags(cmd *cobra.Command) {
 }
 
 func (o *registerOpts) Run(cmd *cobra.Command, args []string) error {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	c, conn, err := daemonClient()
 	if err != nil {
-		return fmt.Errorf("failed to connect to gRPC server at localhost:50051: %v", err)
+		return err
 	}
 	defer conn.Close()
-	c := svctl.NewServersClient(conn)
 
 	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second)
 	defer cancel()
@@ -60,7 +56,7 @@ func (o *registerOpts) Run(cmd *cobra.Command, args []string) error {
 
 	r, err := c.Register(ctx, &svctl.ServerOpts{Path: path})
 	if err != nil {
-		return fmt.Errorf("error calling function Start: %v", err)
+		return rpcError("Register", err)
 	}
 
 	cmd.Printf("Server status: %v\n", r.GetStatus().String())
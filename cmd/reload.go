@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type reloadOpts struct {
+	*serverOpts
+	all bool
+}
+
+func newReloadOpts() *reloadOpts {
+	return &reloadOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func reloadCmd() *cobra.Command {
+	opts := newReloadOpts()
+
+	cmd := &cobra.Command{
+		Use:   "reload",
+		Short: "Reload the server settings in the daemon",
+		Long: `Reload .svctl/config.yaml, the loggers and templates of a server without restarting it.
+The daemon does this by itself when they change, and for all servers on SIGHUP.
+Invalid settings are reported and the old ones stay in use.`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *reloadOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.all, "all", false, "Reload all registered servers")
+}
+
+func (o *reloadOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), 10*time.Second)
+	defer cancel()
+
+	var path string
+	if !o.all {
+		path, err = o.Path()
+		if err != nil {
+			return err
+		}
+	}
+
+	r, err := c.Reload(ctx, &svctl.ReloadOpts{
+		Path: path,
+	})
+	if err != nil {
+		return rpcError("Reload", err)
+	}
+
+	for _, p := range r.GetPaths() {
+		cmd.Printf("Reloaded %s\n", p)
+	}
+
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(reloadCmd())
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type resetOpts struct {
+	*serverOpts
+	*waitOpts
+}
+
+func newResetOpts() *resetOpts {
+	return &resetOpts{
+		serverOpts: newServerOpts(),
+		waitOpts:   newWaitOpts(),
+	}
+}
+
+func resetCmd() *cobra.Command {
+	opts := newResetOpts()
+
+	cmd := &cobra.Command{
+		Use:          "reset",
+		Short:        "Clears the error of an errored server and starts it again",
+		Long:         `Clears the error of a server parked in the Errored state, resets its restart budget and starts it again`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *resetOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	o.waitOpts.AddFlags(cmd)
+}
+
+func (o *resetOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := o.waitOpts.Context(cmd.Context(), 5*time.Second)
+	defer cancel()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Reset(ctx, &svctl.ActionOpts{Path: path, Wait: o.wait})
+	if err != nil {
+		return rpcError("Reset", err)
+	}
+
+	if o.wait {
+		cmd.Println("Server running")
+		return nil
+	}
+
+	cmd.Printf("Server reset: %v\n", r.GetStatus().String())
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(resetCmd())
+}
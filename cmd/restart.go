@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type restartOpts struct {
+	*serverOpts
+	*waitOpts
+}
+
+func newRestartOpts() *restartOpts {
+	return &restartOpts{
+		serverOpts: newServerOpts(),
+		waitOpts:   newWaitOpts(),
+	}
+}
+
+func restartCmd() *cobra.Command {
+	opts := newRestartOpts()
+
+	cmd := &cobra.Command{
+		Use:          "restart",
+		Short:        "Restarts the server",
+		Long:         `Restarts the server`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *restartOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	o.waitOpts.AddFlags(cmd)
+}
+
+func (o *restartOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := o.waitOpts.Context(cmd.Context(), 5*time.Second)
+	defer cancel()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	r, err := c.Restart(ctx, &svctl.ActionOpts{Path: path, Wait: o.wait})
+	if err != nil {
+		return rpcError("Restart", err)
+	}
+
+	if o.wait {
+		cmd.Println("Server running")
+		return nil
+	}
+
+	cmd.Printf("Server restarted: %v\n", r.GetStatus().String())
+	return nil
+}
+
+func init() {
+	rootCmd.AddCommand(restartCmd())
+}
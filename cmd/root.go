@@ -35,6 +35,7 @@ func ExecuteNoExit() error {
 	defer cancel()
 
 	rootCmd.PersistentFlags().Bool("debug", false, "Enable debug mode")
+	clientFlags.AddFlags(rootCmd.PersistentFlags())
 
 	return rootCmd.ExecuteContext(ctx)
 }
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+func scheduleCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Manage scheduled actions of servers",
+	}
+
+	cmd.AddCommand(scheduleListCmd())
+	cmd.AddCommand(scheduleSkipCmd())
+
+	return cmd
+}
+
+type scheduleListOpts struct {
+	*serverOpts
+	*watchOpts
+}
+
+func newScheduleListOpts() *scheduleListOpts {
+	return &scheduleListOpts{
+		serverOpts: newServerOpts(),
+		watchOpts:  newWatchOpts(),
+	}
+}
+
+func scheduleListCmd() *cobra.Command {
+	opts := newScheduleListOpts()
+
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "List scheduled actions",
+		Long:         `List scheduled actions of the server given by --path, or of all servers when --path is not set`,
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *scheduleListOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	o.watchOpts.AddFlags(cmd)
+}
+
+func (o *scheduleListOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var path string
+	if cmd.Flags().Changed("path") {
+		path, err = o.Path()
+		if err != nil {
+			return err
+		}
+	}
+
+	return o.watchOpts.Run(cmd, func(ctx context.Context, out io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		r, err := c.ListSchedules(ctx, &svctl.ListSchedulesOpts{Path: path})
+		if err != nil {
+			return rpcError("ListSchedules", err)
+		}
+
+		return printSchedules(out, r.GetSchedules()...)
+	})
+}
+
+type scheduleSkipOpts struct {
+	*serverOpts
+	undo bool
+}
+
+func newScheduleSkipOpts() *scheduleSkipOpts {
+	return &scheduleSkipOpts{
+		serverOpts: newServerOpts(),
+	}
+}
+
+func scheduleSkipCmd() *cobra.Command {
+	opts := newScheduleSkipOpts()
+
+	cmd := &cobra.Command{
+		Use:          "skip NAME",
+		Short:        "Skip the next run of a scheduled action",
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *scheduleSkipOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.undo, "undo", false, "Run the next scheduled action after all")
+}
+
+func (o *scheduleSkipOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second)
+	defer cancel()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	r, err := c.SkipSchedule(ctx, &svctl.SkipScheduleOpts{
+		Path: path,
+		Name: args[0],
+		Skip: !o.undo,
+	})
+	if err != nil {
+		return rpcError("SkipSchedule", err)
+	}
+
+	next := r.GetNextRun().AsTime().Local().Format(time.RFC3339)
+	if r.GetSkipNext() {
+		cmd.Printf("Skipping %s at %s\n", r.GetName(), next)
+	} else {
+		cmd.Printf("Running %s at %s\n", r.GetName(), next)
+	}
+
+	return nil
+}
+
+func printSchedules(out io.Writer, schedules ...*svctl.Schedule) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "PATH\tNAME\tCRON\tACTION\tNEXT RUN\tLAST RUN\tLAST ERROR")
+
+	for _, s := range schedules {
+		next := s.GetNextRun().AsTime().Local().Format(time.RFC3339)
+		if s.GetSkipNext() {
+			next += " (skipped)"
+		}
+
+		last := "-"
+		if s.GetLastRun() != nil {
+			last = s.GetLastRun().AsTime().Local().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.GetPath(),
+			s.GetName(),
+			s.GetCron(),
+			s.GetAction(),
+			next,
+			last,
+			s.GetLastError(),
+		)
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd())
+}
@@ -7,3 +7,7 @@ import "os"
 var shutdownSignals = []os.Signal{
 	os.Interrupt,
 }
+
+var reloadSignals []os.Signal
+
+var ignoredSignals []os.Signal
@@ -11,3 +11,14 @@ var shutdownSignals = []os.Signal{
 	os.Interrupt,
 	syscall.SIGTERM,
 }
+
+var reloadSignals = []os.Signal{
+	syscall.SIGHUP,
+}
+
+// ignoredSignals are ignored by the daemon and so by the servers it starts,
+// which inherit them. A server printing something after the daemon that
+// read its output exited gets an error instead of being killed by SIGPIPE.
+var ignoredSignals = []os.Signal{
+	syscall.SIGPIPE,
+}
@@ -1,23 +1,21 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	"github.com/sboon-gg/svctl/svctl"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type startOpts struct {
 	*serverOpts
+	*waitOpts
 }
 
 func newStartOpts() *startOpts {
 	return &startOpts{
 		serverOpts: newServerOpts(),
+		waitOpts:   newWaitOpts(),
 	}
 }
 
@@ -39,17 +37,17 @@ func startCmd() *cobra.Command {
 
 func (o *startOpts) AddFlags(cmd *cobra.Command) {
 	o.serverOpts.AddFlags(cmd)
+	o.waitOpts.AddFlags(cmd)
 }
 
 func (o *startOpts) Run(cmd *cobra.Command, args []string) error {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	c, conn, err := daemonClient()
 	if err != nil {
-		return fmt.Errorf("failed to connect to gRPC server at localhost:50051: %v", err)
+		return err
 	}
 	defer conn.Close()
-	c := svctl.NewServersClient(conn)
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), time.Second)
+	ctx, cancel := o.waitOpts.Context(cmd.Context(), time.Second)
 	defer cancel()
 
 	path, err := o.Path()
@@ -57,9 +55,14 @@ func (o *startOpts) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	r, err := c.Start(ctx, &svctl.ServerOpts{Path: path})
+	r, err := c.Start(ctx, &svctl.ActionOpts{Path: path, Wait: o.wait})
 	if err != nil {
-		return fmt.Errorf("error calling function Start: %v", err)
+		return rpcError("Start", err)
+	}
+
+	if o.wait {
+		cmd.Println("Server running")
+		return nil
 	}
 
 	cmd.Printf("Server started: %v\n", r.GetStatus().String())
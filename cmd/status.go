@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/spf13/cobra"
+)
+
+type watchOpts struct {
+	watch    bool
+	interval time.Duration
+}
+
+func newWatchOpts() *watchOpts {
+	return &watchOpts{
+		interval: 2 * time.Second,
+	}
+}
+
+func (o *watchOpts) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.watch, "watch", "w", o.watch, "Keep refreshing the output")
+	cmd.Flags().DurationVar(&o.interval, "interval", o.interval, "Refresh interval used with --watch")
+}
+
+// Run calls fn once, or until the command is cancelled when watching.
+func (o *watchOpts) Run(cmd *cobra.Command, fn func(ctx context.Context, out io.Writer) error) error {
+	if !o.watch {
+		return fn(cmd.Context(), cmd.OutOrStdout())
+	}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		// Clear screen and move cursor to the top left corner
+		cmd.Print("\033[H\033[2J")
+
+		err := fn(cmd.Context(), cmd.OutOrStdout())
+		if err != nil {
+			cmd.PrintErrln(err)
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+type statusOpts struct {
+	*serverOpts
+	*watchOpts
+}
+
+func newStatusOpts() *statusOpts {
+	return &statusOpts{
+		serverOpts: newServerOpts(),
+		watchOpts:  newWatchOpts(),
+	}
+}
+
+func statusCmd() *cobra.Command {
+	opts := newStatusOpts()
+
+	cmd := &cobra.Command{
+		Use:          "status",
+		Short:        "Show the status of the server",
+		SilenceUsage: true,
+		RunE:         opts.Run,
+	}
+
+	opts.AddFlags(cmd)
+
+	return cmd
+}
+
+func (o *statusOpts) AddFlags(cmd *cobra.Command) {
+	o.serverOpts.AddFlags(cmd)
+	o.watchOpts.AddFlags(cmd)
+}
+
+func (o *statusOpts) Run(cmd *cobra.Command, args []string) error {
+	c, conn, err := daemonClient()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	path, err := o.Path()
+	if err != nil {
+		return err
+	}
+
+	return o.watchOpts.Run(cmd, func(ctx context.Context, out io.Writer) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Second)
+		defer cancel()
+
+		r, err := c.GetServer(ctx, &svctl.ServerOpts{Path: path})
+		if err != nil {
+			return rpcError("GetServer", err)
+		}
+
+		return printServers(out, r)
+	})
+}
+
+func printServers(out io.Writer, servers ...*svctl.ServerStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "PATH\tSTATE\tDESIRED\tPID\tUPTIME\tRESTARTS\tVERSION\tLAST ERROR")
+	for _, s := range servers {
+		pid := "-"
+		if s.GetPid() > 0 {
+			pid = fmt.Sprint(s.GetPid())
+		}
+
+		uptime := "-"
+		if d := s.GetUptime().AsDuration(); d > 0 {
+			uptime = d.Truncate(time.Second).String()
+		}
+
+		state := s.GetState()
+		if s.GetNextRestart() != nil {
+			in := time.Until(s.GetNextRestart().AsTime()).Round(time.Second)
+			state = fmt.Sprintf("%s (restart in %s)", state, max(in, 0))
+		}
+
+		desired := "stopped"
+		if s.GetDesiredRunning() {
+			desired = "running"
+		}
+		if s.GetAutostart() {
+			desired += " (autostart)"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			s.GetPath(),
+			state,
+			desired,
+			pid,
+			uptime,
+			s.GetRestarts(),
+			s.GetVersion(),
+			s.GetLastError(),
+		)
+	}
+
+	return w.Flush()
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd())
+}
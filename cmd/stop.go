@@ -1,23 +1,21 @@
 package cmd
 
 import (
-	"context"
-	"fmt"
 	"time"
 
 	"github.com/sboon-gg/svctl/svctl"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 type stopOpts struct {
 	*serverOpts
+	*waitOpts
 }
 
 func newStopOpts() *stopOpts {
 	return &stopOpts{
 		serverOpts: newServerOpts(),
+		waitOpts:   newWaitOpts(),
 	}
 }
 
@@ -39,17 +37,17 @@ func stopCmd() *cobra.Command {
 
 func (o *stopOpts) AddFlags(cmd *cobra.Command) {
 	o.serverOpts.AddFlags(cmd)
+	o.waitOpts.AddFlags(cmd)
 }
 
 func (o *stopOpts) Run(cmd *cobra.Command, args []string) error {
-	conn, err := grpc.Dial("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	c, conn, err := daemonClient()
 	if err != nil {
-		return fmt.Errorf("failed to connect to gRPC server at localhost:50051: %v", err)
+		return err
 	}
 	defer conn.Close()
-	c := svctl.NewServersClient(conn)
 
-	ctx, cancel := context.WithTimeout(cmd.Context(), 5*time.Second)
+	ctx, cancel := o.waitOpts.Context(cmd.Context(), 5*time.Second)
 	defer cancel()
 
 	path, err := o.Path()
@@ -57,9 +55,14 @@ func (o *stopOpts) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	r, err := c.Stop(ctx, &svctl.ServerOpts{Path: path})
+	r, err := c.Stop(ctx, &svctl.ActionOpts{Path: path, Wait: o.wait})
 	if err != nil {
-		return fmt.Errorf("error calling function Stop: %v", err)
+		return rpcError("Stop", err)
+	}
+
+	if o.wait {
+		cmd.Println("Server stopped")
+		return nil
 	}
 
 	cmd.Printf("Server status: %v\n", r.GetStatus().String())
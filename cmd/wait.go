@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type waitOpts struct {
+	wait    bool
+	timeout time.Duration
+}
+
+func newWaitOpts() *waitOpts {
+	return &waitOpts{
+		timeout: 2 * time.Minute,
+	}
+}
+
+func (o *waitOpts) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.wait, "wait", o.wait, "Wait until the server reached the target state and report why it failed to")
+	cmd.Flags().DurationVar(&o.timeout, "timeout", o.timeout, "How long to wait with --wait")
+}
+
+// Context bounds the call by timeout when waiting and by callTimeout
+// otherwise.
+func (o *waitOpts) Context(parent context.Context, callTimeout time.Duration) (context.Context, context.CancelFunc) {
+	if o.wait {
+		return context.WithTimeout(parent, o.timeout)
+	}
+
+	return context.WithTimeout(parent, callTimeout)
+}
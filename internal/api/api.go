@@ -2,9 +2,17 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
+	"github.com/sboon-gg/svctl/internal/auth"
 	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/scheduler"
 	"github.com/sboon-gg/svctl/svctl"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type daemonServer struct {
@@ -21,7 +29,7 @@ func NewDaemonServer(daemon *daemon.Daemon) svctl.ServersServer {
 func (s *daemonServer) Register(ctx context.Context, opts *svctl.ServerOpts) (*svctl.ServerInfo, error) {
 	err := s.daemon.Register(opts.GetPath())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(opts.GetPath(), err)
 	}
 
 	return &svctl.ServerInfo{
@@ -30,10 +38,22 @@ func (s *daemonServer) Register(ctx context.Context, opts *svctl.ServerOpts) (*s
 	}, nil
 }
 
-func (s *daemonServer) Start(ctx context.Context, opts *svctl.ServerOpts) (*svctl.ServerInfo, error) {
-	err := s.daemon.Start(opts.GetPath())
+func (s *daemonServer) Unregister(ctx context.Context, opts *svctl.ServerOpts) (*svctl.ServerInfo, error) {
+	err := s.daemon.Unregister(ctx, opts.GetPath())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return &svctl.ServerInfo{
+		Path:   opts.GetPath(),
+		Status: svctl.Status_UNREGISTERED,
+	}, nil
+}
+
+func (s *daemonServer) Start(ctx context.Context, opts *svctl.ActionOpts) (*svctl.ServerInfo, error) {
+	err := s.daemon.Start(ctx, opts.GetPath(), opts.GetWait())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
 	}
 
 	return &svctl.ServerInfo{
@@ -42,10 +62,10 @@ func (s *daemonServer) Start(ctx context.Context, opts *svctl.ServerOpts) (*svct
 	}, nil
 }
 
-func (s *daemonServer) Stop(ctx context.Context, opts *svctl.ServerOpts) (*svctl.ServerInfo, error) {
-	err := s.daemon.Stop(opts.GetPath())
+func (s *daemonServer) Stop(ctx context.Context, opts *svctl.ActionOpts) (*svctl.ServerInfo, error) {
+	err := s.daemon.Stop(ctx, opts.GetPath(), opts.GetWait())
 	if err != nil {
-		return nil, err
+		return nil, toStatus(opts.GetPath(), err)
 	}
 
 	return &svctl.ServerInfo{
@@ -53,3 +73,277 @@ func (s *daemonServer) Stop(ctx context.Context, opts *svctl.ServerOpts) (*svctl
 		Status: svctl.Status_STOPPED,
 	}, nil
 }
+
+func (s *daemonServer) Restart(ctx context.Context, opts *svctl.ActionOpts) (*svctl.ServerInfo, error) {
+	err := s.daemon.Restart(ctx, opts.GetPath(), opts.GetWait())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return &svctl.ServerInfo{
+		Path:   opts.GetPath(),
+		Status: svctl.Status_RESTARTED,
+	}, nil
+}
+
+func (s *daemonServer) Reset(ctx context.Context, opts *svctl.ActionOpts) (*svctl.ServerInfo, error) {
+	err := s.daemon.Reset(ctx, opts.GetPath(), opts.GetWait())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return &svctl.ServerInfo{
+		Path:   opts.GetPath(),
+		Status: svctl.Status_RESET,
+	}, nil
+}
+
+func (s *daemonServer) GetServer(ctx context.Context, opts *svctl.ServerOpts) (*svctl.ServerStatus, error) {
+	return s.serverStatus(opts.GetPath())
+}
+
+func (s *daemonServer) ListServers(ctx context.Context, opts *svctl.ListServersOpts) (*svctl.ServerList, error) {
+	list := &svctl.ServerList{}
+
+	for _, path := range s.daemon.Paths() {
+		if !auth.CanView(ctx, path) {
+			continue
+		}
+
+		status, err := s.serverStatus(path)
+		if err != nil {
+			return nil, err
+		}
+
+		list.Servers = append(list.Servers, status)
+	}
+
+	return list, nil
+}
+
+func (s *daemonServer) SetAutostart(ctx context.Context, opts *svctl.AutostartOpts) (*svctl.ServerStatus, error) {
+	_, err := s.daemon.SetAutostart(opts.GetPath(), opts.GetEnabled())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return s.serverStatus(opts.GetPath())
+}
+
+// Reload reloads the settings of one server, or of all servers the caller
+// may change when no path is given.
+func (s *daemonServer) Reload(ctx context.Context, opts *svctl.ReloadOpts) (*svctl.ReloadResult, error) {
+	paths := []string{opts.GetPath()}
+	if opts.GetPath() == "" {
+		paths = nil
+		for _, path := range s.daemon.Paths() {
+			if auth.CanOperate(ctx, path) {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	result := &svctl.ReloadResult{}
+	var errs []error
+
+	for _, path := range paths {
+		err := s.daemon.Reload(path)
+		if err != nil {
+			if opts.GetPath() == "" {
+				err = fmt.Errorf("%s: %w", path, err)
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		result.Paths = append(result.Paths, path)
+	}
+
+	if len(errs) > 0 {
+		return nil, toStatus(opts.GetPath(), errors.Join(errs...))
+	}
+
+	return result, nil
+}
+
+func (s *daemonServer) GetOutput(ctx context.Context, opts *svctl.OutputOpts) (*svctl.Output, error) {
+	lines, err := s.daemon.Output(opts.GetPath(), int(opts.GetLines()))
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return &svctl.Output{Lines: lines}, nil
+}
+
+func (s *daemonServer) serverStatus(path string) (*svctl.ServerStatus, error) {
+	status, err := s.daemon.Status(path)
+	if err != nil {
+		return nil, toStatus(path, err)
+	}
+
+	desired, err := s.daemon.Desired(path)
+	if err != nil {
+		return nil, toStatus(path, err)
+	}
+
+	return serverStatus(path, status, desired), nil
+}
+
+func serverStatus(path string, status *fsm.Status, desired daemon.Desired) *svctl.ServerStatus {
+	s := &svctl.ServerStatus{
+		Path:           path,
+		State:          status.State.String(),
+		Pid:            int32(status.Pid),
+		Uptime:         durationpb.New(status.Uptime),
+		Restarts:       uint32(status.Restarts),
+		Version:        status.Version,
+		DesiredRunning: desired.Running,
+		Autostart:      desired.Autostart,
+	}
+
+	if status.Err != nil {
+		s.LastError = status.Err.Error()
+	}
+
+	if !status.NextRestart.IsZero() {
+		s.NextRestart = timestamppb.New(status.NextRestart)
+	}
+
+	return s
+}
+
+func (s *daemonServer) WatchEvents(opts *svctl.WatchEventsOpts, stream svctl.Servers_WatchEventsServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	history, events, err := s.daemon.Subscribe(ctx, opts.GetPath())
+	if err != nil {
+		return toStatus(opts.GetPath(), err)
+	}
+
+	for _, e := range history {
+		if !auth.CanView(ctx, e.Path) {
+			continue
+		}
+
+		err := stream.Send(event(e))
+		if err != nil {
+			return err
+		}
+	}
+
+	if !opts.GetFollow() {
+		return nil
+	}
+
+	for e := range events {
+		if !auth.CanView(ctx, e.Path) {
+			continue
+		}
+
+		err := stream.Send(event(e))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func event(e fsm.Event) *svctl.Event {
+	ev := &svctl.Event{
+		Path:   e.Path,
+		Time:   timestamppb.New(e.Time),
+		Type:   e.Type.String(),
+		Reason: e.Reason,
+		Pid:    int32(e.Pid),
+	}
+
+	if e.Type == fsm.EventTransition {
+		ev.From = e.From.String()
+		ev.To = e.To.String()
+	}
+
+	if e.Err != nil {
+		ev.Error = e.Err.Error()
+	}
+
+	return ev
+}
+
+func (s *daemonServer) ListSchedules(ctx context.Context, opts *svctl.ListSchedulesOpts) (*svctl.ScheduleList, error) {
+	jobs, err := s.daemon.Schedules(opts.GetPath())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	list := &svctl.ScheduleList{}
+	for _, job := range jobs {
+		if !auth.CanView(ctx, job.Group) {
+			continue
+		}
+
+		list.Schedules = append(list.Schedules, schedule(job))
+	}
+
+	return list, nil
+}
+
+func (s *daemonServer) SkipSchedule(ctx context.Context, opts *svctl.SkipScheduleOpts) (*svctl.Schedule, error) {
+	job, err := s.daemon.SkipSchedule(opts.GetPath(), opts.GetName(), opts.GetSkip())
+	if err != nil {
+		return nil, toStatus(opts.GetPath(), err)
+	}
+
+	return schedule(job), nil
+}
+
+func schedule(job scheduler.Job) *svctl.Schedule {
+	s := &svctl.Schedule{
+		Path:     job.Group,
+		Name:     job.Name,
+		Cron:     job.Spec,
+		Action:   job.Action,
+		NextRun:  timestamppb.New(job.Next),
+		SkipNext: job.Skip,
+	}
+
+	if !job.LastRun.IsZero() {
+		s.LastRun = timestamppb.New(job.LastRun)
+	}
+
+	if job.LastErr != nil {
+		s.LastError = job.LastErr.Error()
+	}
+
+	return s
+}
+
+func (s *daemonServer) Audit(opts *svctl.AuditOpts, stream svctl.Servers_AuditServer) error {
+	var since time.Time
+	if opts.GetSince() != nil {
+		since = opts.GetSince().AsTime()
+	}
+
+	entries, err := s.daemon.Audit().Query(since, opts.GetPath())
+	if err != nil {
+		return toStatus(opts.GetPath(), err)
+	}
+
+	for _, e := range entries {
+		err := stream.Send(&svctl.AuditEntry{
+			Time:   timestamppb.New(e.Time),
+			Caller: e.Caller,
+			Peer:   e.Peer,
+			Rpc:    e.RPC,
+			Path:   e.Path,
+			Code:   e.Code,
+			Error:  e.Error,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/scheduler"
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/svctl"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatus maps daemon errors to gRPC status errors, so clients don't have
+// to match error messages. Errors of actions not allowed in the current
+// state carry a StateDetail.
+func toStatus(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var actionErr *fsm.ActionError
+
+	switch {
+	case errors.As(err, &actionErr):
+		st := status.New(codes.FailedPrecondition, err.Error())
+
+		detail := &svctl.StateDetail{
+			Path:   path,
+			State:  actionErr.State.String(),
+			Action: actionErr.Action.String(),
+		}
+		for _, a := range actionErr.Allowed {
+			detail.AllowedActions = append(detail.AllowedActions, a.String())
+		}
+
+		withDetail, detailErr := st.WithDetails(detail)
+		if detailErr != nil {
+			return st.Err()
+		}
+
+		return withDetail.Err()
+	case errors.Is(err, daemon.ErrNotFound), errors.Is(err, scheduler.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, daemon.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, daemon.ErrInvalidPath):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, settings.ErrInvalid):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, fsm.ErrNotReached):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, fsm.ErrClosed):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sboon-gg/svctl/internal/daemon"
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/svctl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus(t *testing.T) {
+	tests := []struct {
+		err  error
+		code codes.Code
+	}{
+		{fmt.Errorf("%w: %q", daemon.ErrNotFound, "/srv"), codes.NotFound},
+		{fmt.Errorf("%w: %q", daemon.ErrAlreadyExists, "/srv"), codes.AlreadyExists},
+		{fmt.Errorf("%w: %q", daemon.ErrInvalidPath, "srv"), codes.InvalidArgument},
+		{fsm.ErrClosed, codes.Unavailable},
+		{errors.New("boom"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.code, status.Code(toStatus("/srv", tt.err)), tt.err.Error())
+	}
+
+	assert.NoError(t, toStatus("/srv", nil))
+}
+
+func TestToStatus_StateDetail(t *testing.T) {
+	err := toStatus("/srv", &fsm.ActionError{
+		Action:  fsm.ActionStart,
+		State:   fsm.StateTRunning,
+		Allowed: []fsm.Action{fsm.ActionStop, fsm.ActionRestart},
+	})
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.FailedPrecondition, st.Code())
+
+	require.Len(t, st.Details(), 1)
+	detail, ok := st.Details()[0].(*svctl.StateDetail)
+	require.True(t, ok)
+
+	assert.Equal(t, "/srv", detail.GetPath())
+	assert.Equal(t, "Running", detail.GetState())
+	assert.Equal(t, "Start", detail.GetAction())
+	assert.Equal(t, []string{"Stop", "Restart"}, detail.GetAllowedActions())
+}
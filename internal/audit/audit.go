@@ -0,0 +1,406 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxSize is the size in bytes the log is rotated at by default.
+const DefaultMaxSize = 10 << 20
+
+// Config limits the log files.
+type Config struct {
+	// MaxSize is the size in bytes the log is rotated at
+	MaxSize int64
+	// MaxFiles is how many rotated files are kept, all of them when zero
+	MaxFiles int
+}
+
+// Entry records a single API call.
+type Entry struct {
+	Time time.Time `json:"time"`
+	// Caller is the authenticated identity, like unix:1000 or token:laptop
+	Caller string  `json:"caller"`
+	Peer   string  `json:"peer,omitempty"`
+	Uid    *uint32 `json:"uid,omitempty"`
+	Pid    int32   `json:"pid,omitempty"`
+	RPC    string  `json:"rpc"`
+	Path   string  `json:"path,omitempty"`
+	// Code is the gRPC status code of the result
+	Code  string `json:"code"`
+	Error string `json:"error,omitempty"`
+}
+
+// Log is an append-only JSON-lines audit log. Once the log reaches its max
+// size it is rotated to path.1, older files move up to path.2 and so on.
+type Log struct {
+	mu     sync.Mutex
+	path   string
+	config Config
+	file   *os.File
+	size   int64
+}
+
+// Open opens the log at path, keeping all rotated files until configured
+// otherwise.
+func Open(path string) (*Log, error) {
+	l := &Log{
+		path:   path,
+		config: Config{MaxSize: DefaultMaxSize},
+	}
+
+	err := l.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+func (l *Log) open() error {
+	file, err := os.OpenFile(l.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	l.file = file
+	l.size = fi.Size()
+
+	return nil
+}
+
+// Configure applies config from the next write on.
+func (l *Log) Configure(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.config = config
+}
+
+func (l *Log) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+
+	return l.file.Close()
+}
+
+func (l *Log) Write(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var rotateErr error
+	if l.file != nil && l.size > 0 && l.size+int64(len(line)) > l.config.MaxSize {
+		// The entry is still recorded when rotating fails, it is tried
+		// again on the next write
+		rotateErr = l.rotate()
+	}
+
+	// Reopening the log after rotating failed
+	if l.file == nil {
+		err := l.open()
+		if err != nil {
+			return errors.Join(rotateErr, err)
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+
+	return errors.Join(rotateErr, err)
+}
+
+// rotate must be called with l.mu held. The log is reopened at path even
+// when moving the files fails, l.file is nil when that fails too.
+func (l *Log) rotate() error {
+	err := l.file.Close()
+	l.file = nil
+	if err != nil {
+		return errors.Join(err, l.open())
+	}
+
+	err = l.moveFiles()
+
+	return errors.Join(err, l.open())
+}
+
+// moveFiles moves the log to path.1 and the rotated files up, removing
+// those beyond MaxFiles.
+func (l *Log) moveFiles() error {
+	var err error
+
+	n := l.rotated()
+	for i := n; i >= 1; i-- {
+		err = os.Rename(l.rotatedPath(i), l.rotatedPath(i+1))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = os.Rename(l.path, l.rotatedPath(1))
+	if err != nil {
+		return err
+	}
+
+	if l.config.MaxFiles > 0 {
+		for i := l.config.MaxFiles + 1; i <= n+1; i++ {
+			err = os.Remove(l.rotatedPath(i))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// rotated returns how many rotated files there are.
+func (l *Log) rotated() int {
+	n := 0
+	for {
+		_, err := os.Stat(l.rotatedPath(n + 1))
+		if err != nil {
+			return n
+		}
+		n++
+	}
+}
+
+func (l *Log) rotatedPath(i int) string {
+	return fmt.Sprintf("%s.%d", l.path, i)
+}
+
+// Query returns the entries since the given time, of the server on path
+// when it is not empty, oldest first. Calls are recorded meanwhile.
+func (l *Log) Query(since time.Time, path string) ([]Entry, error) {
+	files, err := l.openFiles(since)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+
+	var entries []Entry
+
+	for _, f := range files {
+		err := readEntries(f, func(e Entry) {
+			if e.Time.Before(since) {
+				return
+			}
+
+			if path != "" && filepath.Clean(e.Path) != filepath.Clean(path) {
+				return
+			}
+
+			entries = append(entries, e)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// openFiles opens the files with entries since the given time, oldest
+// first. They are opened at once so a rotation cannot move them while they
+// are read, the live file is limited to what was written so far.
+func (l *Log) openFiles(since time.Time) ([]io.ReadCloser, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var files []io.ReadCloser
+
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+
+	for i := l.rotated(); i >= 1; i-- {
+		f, err := os.Open(l.rotatedPath(i))
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		fi, err := f.Stat()
+		if err != nil {
+			f.Close()
+			closeAll()
+			return nil, err
+		}
+
+		// Nothing was written to it since
+		if fi.ModTime().Before(since) {
+			f.Close()
+			continue
+		}
+
+		files = append(files, f)
+	}
+
+	f, err := os.Open(l.path)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+
+	files = append(files, &limitedFile{Reader: io.LimitReader(f, l.size), Closer: f})
+
+	return files, nil
+}
+
+type limitedFile struct {
+	io.Reader
+	io.Closer
+}
+
+func readEntries(file io.Reader, fn func(Entry)) error {
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var e Entry
+
+		// Skip lines torn by a crash rather than failing the whole query
+		if json.Unmarshal(scanner.Bytes(), &e) != nil {
+			continue
+		}
+
+		fn(e)
+	}
+
+	return scanner.Err()
+}
+
+func (l *Log) record(ctx context.Context, method, path string, err error) {
+	e := Entry{
+		Time: time.Now(),
+		RPC:  method,
+		Path: path,
+		Code: status.Code(err).String(),
+	}
+
+	if id, ok := auth.FromContext(ctx); ok {
+		e.Caller = id.String()
+		e.Peer = id.Addr
+
+		if id.Kind == auth.KindUnix {
+			uid := id.Uid
+			e.Uid = &uid
+			e.Pid = id.Pid
+		}
+	}
+
+	if err != nil {
+		e.Error = status.Convert(err).Message()
+	}
+
+	l.writeOrLog(e)
+}
+
+// Scheduled records an action the scheduler ran on the server on path for
+// the schedule name.
+func (l *Log) Scheduled(name, action, path string, err error) {
+	e := Entry{
+		Time:   time.Now(),
+		Caller: "scheduler:" + name,
+		RPC:    action,
+		Path:   path,
+		Code:   status.Code(err).String(),
+	}
+
+	if err != nil {
+		e.Error = err.Error()
+	}
+
+	l.writeOrLog(e)
+}
+
+// writeOrLog writes e. Failing to audit must not fail the action, the error
+// is logged instead.
+func (l *Log) writeOrLog(e Entry) {
+	err := l.Write(e)
+	if err != nil {
+		slog.Error("Failed to write audit log", "error", err.Error())
+	}
+}
+
+type pathRequest interface {
+	GetPath() string
+}
+
+func requestPath(req any) string {
+	if r, ok := req.(pathRequest); ok {
+		return r.GetPath()
+	}
+
+	return ""
+}
+
+// UnaryInterceptor records every call. It must run after the authenticator
+// and before authorization, so denied calls are recorded with their caller.
+func (l *Log) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	resp, err := handler(ctx, req)
+
+	l.record(ctx, info.FullMethod, requestPath(req), err)
+
+	return resp, err
+}
+
+// StreamInterceptor records streams once they end.
+func (l *Log) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	stream := &recordingStream{ServerStream: ss}
+
+	err := handler(srv, stream)
+
+	l.record(ss.Context(), info.FullMethod, stream.path, err)
+
+	return err
+}
+
+// recordingStream remembers the target path of the request.
+type recordingStream struct {
+	grpc.ServerStream
+	path string
+}
+
+func (s *recordingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.path == "" {
+		s.path = requestPath(m)
+	}
+
+	return err
+}
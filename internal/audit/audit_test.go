@@ -0,0 +1,118 @@
+package audit
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type pathReq struct {
+	path string
+}
+
+func (r pathReq) GetPath() string {
+	return r.path
+}
+
+func TestLog_Interceptor(t *testing.T) {
+	l, err := Open(filepath.Join(t.TempDir(), "audit.log"))
+	require.NoError(t, err)
+	defer l.Close()
+
+	ctx := auth.NewContext(context.Background(), auth.Identity{Kind: auth.KindUnix, Name: "1000", Uid: 1000})
+
+	ok := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	denied := func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.PermissionDenied, "denied")
+	}
+
+	_, err = l.UnaryInterceptor(ctx, pathReq{"/srv/pr1"}, &grpc.UnaryServerInfo{FullMethod: "/svctl.Servers/Restart"}, ok)
+	require.NoError(t, err)
+
+	_, err = l.UnaryInterceptor(ctx, pathReq{"/srv/pr2"}, &grpc.UnaryServerInfo{FullMethod: "/svctl.Servers/Unregister"}, denied)
+	require.Error(t, err)
+
+	entries, err := l.Query(time.Time{}, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	assert.Equal(t, "unix:1000", entries[0].Caller)
+	assert.Equal(t, uint32(1000), *entries[0].Uid)
+	assert.Equal(t, "/svctl.Servers/Restart", entries[0].RPC)
+	assert.Equal(t, "/srv/pr1", entries[0].Path)
+	assert.Equal(t, "OK", entries[0].Code)
+
+	assert.Equal(t, "PermissionDenied", entries[1].Code)
+	assert.Contains(t, entries[1].Error, "denied")
+
+	entries, err = l.Query(time.Time{}, "/srv/pr2")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	entries, err = l.Query(time.Now().Add(time.Hour), "")
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestLog_Rotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	l, err := Open(path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	// Every entry goes into a file of its own
+	l.Configure(Config{MaxSize: 1})
+
+	for _, rpc := range []string{"first", "second", "third", "fourth"} {
+		require.NoError(t, l.Write(Entry{Time: time.Now(), RPC: rpc, Code: "OK"}))
+	}
+	assert.FileExists(t, path+".3")
+
+	entries, err := l.Query(time.Time{}, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+	assert.Equal(t, "first", entries[0].RPC)
+	assert.Equal(t, "fourth", entries[3].RPC)
+
+	l.Configure(Config{MaxSize: 1, MaxFiles: 2})
+	require.NoError(t, l.Write(Entry{Time: time.Now(), RPC: "fifth", Code: "OK"}))
+	assert.NoFileExists(t, path+".3")
+
+	entries, err = l.Query(time.Time{}, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "third", entries[0].RPC)
+}
+
+func TestLog_RotateFailure(t *testing.T) {
+	// Names of rotated files are too long
+	path := filepath.Join(t.TempDir(), strings.Repeat("a", 255))
+
+	l, err := Open(path)
+	require.NoError(t, err)
+	defer l.Close()
+
+	l.Configure(Config{MaxSize: 1})
+
+	for _, rpc := range []string{"first", "second", "third"} {
+		err := l.Write(Entry{Time: time.Now(), RPC: rpc, Code: "OK"})
+		if rpc != "first" {
+			assert.Error(t, err)
+		}
+	}
+
+	entries, err := l.Query(time.Time{}, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, "third", entries[2].RPC)
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"crypto/tls"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCA_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+
+	ca, err := NewCA("test CA")
+	require.NoError(t, err)
+	require.NoError(t, ca.WriteFiles(dir, "ca"))
+	require.NoError(t, ca.IssueServer(dir, "server", []string{"localhost", "127.0.0.1"}))
+	require.NoError(t, ca.IssueClient(dir, "alice"))
+
+	file := func(name string) string {
+		return filepath.Join(dir, name)
+	}
+
+	serverConfig, err := ServerTLSConfig(file("server.crt"), file("server.key"), file("ca.crt"))
+	require.NoError(t, err)
+
+	clientConfig, err := ClientTLSConfig(file("ca.crt"), file("alice.crt"), file("alice.key"))
+	require.NoError(t, err)
+	clientConfig.ServerName = "localhost"
+
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	server := tls.Server(serverConn, serverConfig)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tls.Client(clientConn, clientConfig).Handshake()
+	}()
+
+	require.NoError(t, server.Handshake())
+	require.NoError(t, <-done)
+
+	state := server.ConnectionState()
+	require.NotEmpty(t, state.VerifiedChains)
+	assert.Equal(t, "alice", state.PeerCertificates[0].Subject.CommonName)
+}
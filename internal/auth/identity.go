@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type IdentityKind string
+
+const (
+	// KindUnix callers are identified by the uid of the process on the
+	// other end of the Unix socket.
+	KindUnix IdentityKind = "unix"
+	// KindCert callers presented a client certificate signed by the CA.
+	KindCert IdentityKind = "cert"
+	// KindToken callers sent a known bearer token.
+	KindToken IdentityKind = "token"
+	// KindAnonymous callers are not authenticated.
+	KindAnonymous IdentityKind = "anonymous"
+)
+
+// Identity is the authenticated caller of an RPC.
+type Identity struct {
+	Kind IdentityKind
+	// Name is the uid, the certificate common name, the token name or the
+	// peer address, depending on Kind
+	Name string
+	Uid  uint32
+	Gid  uint32
+	Pid  int32
+	// Addr is the peer address, empty for Unix socket callers
+	Addr string
+	// Err is set when authentication failed. Such callers are rejected by
+	// the Policy, which lets interceptors in between still see the peer.
+	Err error
+}
+
+func (id Identity) String() string {
+	return fmt.Sprintf("%s:%s", id.Kind, id.Name)
+}
+
+type identityKey struct{}
+
+func NewContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity stored by the Authenticator.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// Authenticator identifies callers by Unix peer credentials, verified client
+// certificates or bearer tokens, in that order of precedence except that a
+// token always has to be valid when sent.
+type Authenticator struct {
+	tokens []Token
+	// required rejects anonymous callers
+	required bool
+}
+
+func NewAuthenticator(tokens []Token, required bool) *Authenticator {
+	return &Authenticator{
+		tokens:   tokens,
+		required: required,
+	}
+}
+
+func (a *Authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	var id Identity
+
+	pr, ok := peer.FromContext(ctx)
+	if ok {
+		id.Addr = pr.Addr.String()
+
+		switch info := pr.AuthInfo.(type) {
+		case PeerCredAuthInfo:
+			id.Kind = KindUnix
+			id.Name = fmt.Sprint(info.Uid)
+			id.Uid = info.Uid
+			id.Gid = info.Gid
+			id.Pid = info.Pid
+			id.Addr = ""
+		case credentials.TLSInfo:
+			if len(info.State.VerifiedChains) > 0 && len(info.State.PeerCertificates) > 0 {
+				id.Kind = KindCert
+				id.Name = info.State.PeerCertificates[0].Subject.CommonName
+			}
+		}
+	}
+
+	token, ok := bearerToken(ctx)
+	if ok {
+		name, ok := a.verify(token)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+
+		if id.Kind != KindUnix {
+			id.Kind = KindToken
+			id.Name = name
+		}
+	}
+
+	if id.Kind == "" {
+		if a.required {
+			return nil, status.Error(codes.Unauthenticated, "a client certificate or token is required")
+		}
+
+		id.Kind = KindAnonymous
+		id.Name = id.Addr
+	}
+
+	return NewContext(ctx, id), nil
+}
+
+func (a *Authenticator) verify(token string) (string, bool) {
+	for _, t := range a.tokens {
+		if t.Matches(token) {
+			return t.Name, true
+		}
+	}
+
+	return "", false
+}
+
+func bearerToken(ctx context.Context) (string, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", false
+	}
+
+	for _, v := range md.Get("authorization") {
+		token, ok := strings.CutPrefix(v, "Bearer ")
+		if ok {
+			return token, true
+		}
+	}
+
+	return "", false
+}
+
+// identify stores the identity of the caller in ctx. Callers failing
+// authentication get an anonymous identity carrying the error.
+func (a *Authenticator) identify(ctx context.Context) context.Context {
+	authCtx, err := a.authenticate(ctx)
+	if err == nil {
+		return authCtx
+	}
+
+	id := Identity{Kind: KindAnonymous, Err: err}
+	if pr, ok := peer.FromContext(ctx); ok {
+		id.Addr = pr.Addr.String()
+		id.Name = id.Addr
+	}
+
+	return NewContext(ctx, id)
+}
+
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	return handler(a.identify(ctx), req)
+}
+
+func (a *Authenticator) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &serverStream{ServerStream: ss, ctx: a.identify(ss.Context())})
+}
+
+// serverStream overrides the context of a stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestAuthenticator(t *testing.T) {
+	token, hash, err := GenerateToken()
+	require.NoError(t, err)
+
+	a := NewAuthenticator([]Token{{Name: "laptop", Hash: hash}}, true)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234},
+	})
+
+	_, err = a.authenticate(ctx)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	bad := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer nope"))
+	_, err = a.authenticate(bad)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	good := metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+	authCtx, err := a.authenticate(good)
+	require.NoError(t, err)
+
+	id, ok := FromContext(authCtx)
+	require.True(t, ok)
+	assert.Equal(t, KindToken, id.Kind)
+	assert.Equal(t, "laptop", id.Name)
+	assert.Equal(t, "10.0.0.1:1234", id.Addr)
+}
+
+func TestAuthenticator_Anonymous(t *testing.T) {
+	a := NewAuthenticator(nil, false)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 1234},
+	})
+
+	authCtx, err := a.authenticate(ctx)
+	require.NoError(t, err)
+
+	id, _ := FromContext(authCtx)
+	assert.Equal(t, KindAnonymous, id.Kind)
+}
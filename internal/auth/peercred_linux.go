@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// PeerCredentialsSupported reports whether Unix socket callers can be
+// identified.
+const PeerCredentialsSupported = true
+
+func peerCred(conn *net.UnixConn) (PeerCredAuthInfo, error) {
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return PeerCredAuthInfo{}, err
+	}
+
+	var (
+		ucred   *unix.Ucred
+		credErr error
+	)
+
+	err = raw.Control(func(fd uintptr) {
+		ucred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	})
+	if err != nil {
+		return PeerCredAuthInfo{}, err
+	}
+	if credErr != nil {
+		return PeerCredAuthInfo{}, credErr
+	}
+
+	return PeerCredAuthInfo{
+		Uid: ucred.Uid,
+		Gid: ucred.Gid,
+		Pid: ucred.Pid,
+	}, nil
+}
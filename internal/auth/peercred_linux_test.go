@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeerCredentials(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.sock")
+
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	defer lis.Close()
+
+	client, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer client.Close()
+
+	conn, err := lis.Accept()
+	require.NoError(t, err)
+
+	_, info, err := PeerCredentials().ServerHandshake(conn)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	cred, ok := info.(PeerCredAuthInfo)
+	require.True(t, ok)
+	assert.Equal(t, uint32(os.Getuid()), cred.Uid)
+	assert.Equal(t, uint32(os.Getgid()), cred.Gid)
+	assert.Equal(t, int32(os.Getpid()), cred.Pid)
+}
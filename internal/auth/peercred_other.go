@@ -0,0 +1,16 @@
+//go:build !linux
+
+package auth
+
+import (
+	"errors"
+	"net"
+)
+
+// PeerCredentialsSupported reports whether Unix socket callers can be
+// identified.
+const PeerCredentialsSupported = false
+
+func peerCred(conn *net.UnixConn) (PeerCredAuthInfo, error) {
+	return PeerCredAuthInfo{}, errors.ErrUnsupported
+}
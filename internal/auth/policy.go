@@ -0,0 +1,474 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Role string
+
+const (
+	// RoleViewer may query servers and events.
+	RoleViewer Role = "viewer"
+	// RoleOperator may also start, stop, restart and reset servers, skip
+	// scheduled actions and set autostart.
+	RoleOperator Role = "operator"
+	// RoleAdmin may do anything, including registering servers.
+	RoleAdmin Role = "admin"
+)
+
+func (r Role) level() int {
+	switch r {
+	case RoleViewer:
+		return 1
+	case RoleOperator:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+
+	return 0
+}
+
+func (r Role) Validate() error {
+	if r.level() == 0 {
+		return fmt.Errorf("invalid role %q", r)
+	}
+
+	return nil
+}
+
+// methodRoles is the least role needed for each method. Methods not listed
+// need RoleAdmin.
+var methodRoles = map[string]Role{
+	"/svctl.Servers/GetServer":     RoleViewer,
+	"/svctl.Servers/ListServers":   RoleViewer,
+	"/svctl.Servers/WatchEvents":   RoleViewer,
+	"/svctl.Servers/ListSchedules": RoleViewer,
+	"/svctl.Servers/Start":         RoleOperator,
+	"/svctl.Servers/Stop":          RoleOperator,
+	"/svctl.Servers/Restart":       RoleOperator,
+	"/svctl.Servers/Reset":         RoleOperator,
+	"/svctl.Servers/SkipSchedule":  RoleOperator,
+	"/svctl.Servers/SetAutostart":  RoleOperator,
+	"/svctl.Servers/Reload":        RoleOperator,
+	"/svctl.Servers/GetOutput":     RoleOperator,
+}
+
+func methodRole(method string) Role {
+	role, ok := methodRoles[method]
+	if !ok {
+		return RoleAdmin
+	}
+
+	return role
+}
+
+// RoleBinding grants Role to the listed Unix users and groups, client
+// certificate common names and token names. A binding with Paths only
+// applies to servers under those paths.
+type RoleBinding struct {
+	Role   Role     `yaml:"role"`
+	Users  []string `yaml:"users,omitempty"`
+	Groups []string `yaml:"groups,omitempty"`
+	Certs  []string `yaml:"certs,omitempty"`
+	Tokens []string `yaml:"tokens,omitempty"`
+	Paths  []string `yaml:"paths,omitempty"`
+}
+
+type binding struct {
+	role   Role
+	uids   []uint32
+	gids   []uint32
+	certs  []string
+	tokens []string
+	paths  []string
+}
+
+// Policy decides what callers may do. Root, the user running the daemon and
+// the allowed Unix users and groups are admins. Everyone else gets the roles
+// bound to them, or the default role.
+type Policy struct {
+	admins   binding
+	bindings []binding
+	// defaultRole applies to callers without a binding
+	defaultRole Role
+	// trustLoopback makes anonymous loopback callers admins
+	trustLoopback bool
+}
+
+// NewPolicy resolves user and group names or numeric ids. When defaultRole
+// is empty, callers without a binding are viewers.
+func NewPolicy(allowUsers, allowGroups []string, bindings []RoleBinding, defaultRole Role) (*Policy, error) {
+	if defaultRole != "" {
+		err := defaultRole.Validate()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	admins, err := resolve(RoleBinding{Role: RoleAdmin, Users: allowUsers, Groups: allowGroups})
+	if err != nil {
+		return nil, err
+	}
+
+	admins.uids = append(admins.uids, 0)
+
+	// There are no uids on Windows
+	if uid := os.Getuid(); uid >= 0 {
+		admins.uids = append(admins.uids, uint32(uid))
+	}
+
+	p := &Policy{
+		admins:      admins,
+		defaultRole: defaultRole,
+	}
+
+	for _, rb := range bindings {
+		err := rb.Role.Validate()
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := resolve(rb)
+		if err != nil {
+			return nil, err
+		}
+
+		p.bindings = append(p.bindings, b)
+	}
+
+	return p, nil
+}
+
+// TrustLoopback treats anonymous callers connecting from a loopback address
+// like the daemon user. Used where Unix sockets are not identified by peer
+// credentials, so local clients can still manage servers over TCP.
+func (p *Policy) TrustLoopback() {
+	p.trustLoopback = true
+}
+
+// RemoteOperators reports whether any caller over TCP may change servers:
+// trusted loopback callers, certificates and tokens bound to operator or
+// admin, or everyone through the default role.
+func (p *Policy) RemoteOperators() bool {
+	if p.trustLoopback || p.defaultRole.level() >= RoleOperator.level() {
+		return true
+	}
+
+	for _, b := range p.bindings {
+		if b.role.level() >= RoleOperator.level() && (len(b.certs) > 0 || len(b.tokens) > 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func resolve(rb RoleBinding) (binding, error) {
+	b := binding{
+		role:   rb.Role,
+		certs:  rb.Certs,
+		tokens: rb.Tokens,
+	}
+
+	for _, name := range rb.Users {
+		uid, err := lookupID(name, func(name string) (string, error) {
+			u, err := user.Lookup(name)
+			if err != nil {
+				return "", err
+			}
+			return u.Uid, nil
+		})
+		if err != nil {
+			return binding{}, fmt.Errorf("user %q: %w", name, err)
+		}
+
+		b.uids = append(b.uids, uid)
+	}
+
+	for _, name := range rb.Groups {
+		gid, err := lookupID(name, func(name string) (string, error) {
+			g, err := user.LookupGroup(name)
+			if err != nil {
+				return "", err
+			}
+			return g.Gid, nil
+		})
+		if err != nil {
+			return binding{}, fmt.Errorf("group %q: %w", name, err)
+		}
+
+		b.gids = append(b.gids, gid)
+	}
+
+	for _, path := range rb.Paths {
+		b.paths = append(b.paths, filepath.Clean(path))
+	}
+
+	return b, nil
+}
+
+func lookupID(name string, lookup func(string) (string, error)) (uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+
+	id, err := lookup(name)
+	if err != nil {
+		return 0, err
+	}
+
+	parsed, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(parsed), nil
+}
+
+// Access lists the roles granted to a caller.
+type Access struct {
+	grants []binding
+}
+
+// Allows reports whether role is granted for the server on path. An empty
+// path stands for all servers and is allowed by path scoped grants too,
+// results are expected to be filtered with Allows per server.
+func (a *Access) Allows(role Role, path string) bool {
+	for _, g := range a.grants {
+		if g.role.level() >= role.level() && g.covers(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b binding) covers(path string) bool {
+	if len(b.paths) == 0 || path == "" {
+		return true
+	}
+
+	path = filepath.Clean(path)
+
+	for _, scope := range b.paths {
+		if path == scope || strings.HasPrefix(path, scope+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (b binding) matches(id Identity, groups []uint32) bool {
+	switch id.Kind {
+	case KindUnix:
+		if slices.Contains(b.uids, id.Uid) {
+			return true
+		}
+
+		for _, gid := range groups {
+			if slices.Contains(b.gids, gid) {
+				return true
+			}
+		}
+	case KindCert:
+		return slices.Contains(b.certs, id.Name)
+	case KindToken:
+		return slices.Contains(b.tokens, id.Name)
+	}
+
+	return false
+}
+
+// Access resolves the roles granted to id.
+func (p *Policy) Access(id Identity) *Access {
+	var groups []uint32
+	if id.Kind == KindUnix {
+		groups = userGroups(id.Uid, id.Gid)
+	}
+
+	if p.admins.matches(id, groups) || p.trustLoopback && id.Kind == KindAnonymous && isLoopback(id.Addr) {
+		return &Access{grants: []binding{{role: RoleAdmin}}}
+	}
+
+	access := &Access{}
+	for _, b := range p.bindings {
+		if b.matches(id, groups) {
+			access.grants = append(access.grants, b)
+		}
+	}
+
+	if len(access.grants) > 0 {
+		return access
+	}
+
+	role := p.defaultRole
+	if role == "" {
+		role = RoleViewer
+	}
+
+	access.grants = []binding{{role: role}}
+
+	return access
+}
+
+func isLoopback(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.IsLoopback()
+}
+
+// userGroups returns the primary and supplementary groups of uid.
+func userGroups(uid, gid uint32) []uint32 {
+	groups := []uint32{gid}
+
+	u, err := user.LookupId(strconv.FormatUint(uint64(uid), 10))
+	if err != nil {
+		return groups
+	}
+
+	ids, err := u.GroupIds()
+	if err != nil {
+		return groups
+	}
+
+	for _, g := range ids {
+		id, err := strconv.ParseUint(g, 10, 32)
+		if err == nil {
+			groups = append(groups, uint32(id))
+		}
+	}
+
+	return groups
+}
+
+type accessKey struct{}
+
+// AccessFromContext returns the access stored by the Policy interceptors.
+func AccessFromContext(ctx context.Context) (*Access, bool) {
+	access, ok := ctx.Value(accessKey{}).(*Access)
+	return access, ok
+}
+
+// CanView reports whether the caller may see the server on path. Calls
+// that went through no Policy can see everything.
+func CanView(ctx context.Context, path string) bool {
+	access, ok := AccessFromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	return access.Allows(RoleViewer, path)
+}
+
+// CanOperate reports whether the caller may change the server on path.
+// Calls that went through no Policy can change everything.
+func CanOperate(ctx context.Context, path string) bool {
+	access, ok := AccessFromContext(ctx)
+	if !ok {
+		return true
+	}
+
+	return access.Allows(RoleOperator, path)
+}
+
+// authorize checks the caller of method against the server on path and
+// returns the context carrying its access. Must run after the Authenticator.
+func (p *Policy) authorize(ctx context.Context, method, path string) (context.Context, error) {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller is not authenticated")
+	}
+
+	if id.Err != nil {
+		return nil, id.Err
+	}
+
+	access := p.Access(id)
+
+	role := methodRole(method)
+	if !access.Allows(role, path) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s on %q, %s role required", id, method, path, role)
+	}
+
+	return context.WithValue(ctx, accessKey{}, access), nil
+}
+
+type pathRequest interface {
+	GetPath() string
+}
+
+func requestPath(req any) string {
+	if r, ok := req.(pathRequest); ok {
+		return r.GetPath()
+	}
+
+	return ""
+}
+
+func (p *Policy) UnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := p.authorize(ctx, info.FullMethod, requestPath(req))
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// StreamInterceptor authorizes streams once their request was received,
+// since only then the target path is known.
+func (p *Policy) StreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &authorizingStream{
+		ServerStream: ss,
+		ctx:          ss.Context(),
+		authorize: func(ctx context.Context, path string) (context.Context, error) {
+			return p.authorize(ctx, info.FullMethod, path)
+		},
+	})
+}
+
+type authorizingStream struct {
+	grpc.ServerStream
+	ctx        context.Context
+	authorize  func(context.Context, string) (context.Context, error)
+	authorized bool
+}
+
+func (s *authorizingStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *authorizingStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil || s.authorized {
+		return err
+	}
+
+	ctx, err := s.authorize(s.ctx, requestPath(m))
+	if err != nil {
+		return err
+	}
+
+	s.ctx = ctx
+	s.authorized = true
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy_Access(t *testing.T) {
+	p, err := NewPolicy([]string{"4242"}, []string{"4343"}, []RoleBinding{
+		{Role: RoleOperator, Users: []string{"5000"}, Paths: []string{"/srv/pr1"}},
+		{Role: RoleViewer, Certs: []string{"alice"}},
+		{Role: RoleOperator, Tokens: []string{"laptop"}},
+	}, "")
+	require.NoError(t, err)
+
+	unix := func(uid, gid uint32) Identity {
+		return Identity{Kind: KindUnix, Uid: uid, Gid: gid}
+	}
+
+	assert.True(t, p.Access(unix(0, 0)).Allows(RoleAdmin, "/srv/pr1"))
+	assert.True(t, p.Access(unix(uint32(os.Getuid()), 1)).Allows(RoleAdmin, ""))
+	assert.True(t, p.Access(unix(4242, 1)).Allows(RoleAdmin, ""))
+	assert.True(t, p.Access(unix(4444, 4343)).Allows(RoleAdmin, ""))
+
+	scoped := p.Access(unix(5000, 5000))
+	assert.True(t, scoped.Allows(RoleOperator, "/srv/pr1"))
+	assert.True(t, scoped.Allows(RoleOperator, "/srv/pr1/sub"))
+	assert.False(t, scoped.Allows(RoleOperator, "/srv/pr10"))
+	assert.False(t, scoped.Allows(RoleAdmin, "/srv/pr1"))
+
+	cert := p.Access(Identity{Kind: KindCert, Name: "alice"})
+	assert.True(t, cert.Allows(RoleViewer, "/srv/pr2"))
+	assert.False(t, cert.Allows(RoleOperator, "/srv/pr2"))
+
+	token := p.Access(Identity{Kind: KindToken, Name: "laptop"})
+	assert.True(t, token.Allows(RoleOperator, "/srv/pr2"))
+	assert.False(t, token.Allows(RoleAdmin, "/srv/pr2"))
+
+	// No binding, so the default role applies
+	assert.False(t, p.Access(unix(6000, 6000)).Allows(RoleOperator, ""))
+	assert.False(t, p.Access(Identity{Kind: KindCert, Name: "bob"}).Allows(RoleOperator, ""))
+
+	_, err = NewPolicy([]string{"no-such-user-svctl"}, nil, nil, "")
+	assert.Error(t, err)
+
+	_, err = NewPolicy(nil, nil, []RoleBinding{{Role: "superuser"}}, "")
+	assert.Error(t, err)
+}
+
+func TestPolicy_DefaultRole(t *testing.T) {
+	p, err := NewPolicy(nil, nil, nil, "")
+	require.NoError(t, err)
+
+	// Nobody gets more than viewer without a binding
+	for _, id := range []Identity{
+		{Kind: KindToken, Name: "laptop"},
+		{Kind: KindCert, Name: "alice"},
+		{Kind: KindAnonymous, Name: "127.0.0.1:4242"},
+		{Kind: KindUnix, Uid: 6000, Gid: 6000},
+	} {
+		assert.True(t, p.Access(id).Allows(RoleViewer, ""), id.String())
+		assert.False(t, p.Access(id).Allows(RoleOperator, ""), id.String())
+	}
+
+	p, err = NewPolicy(nil, nil, nil, RoleOperator)
+	require.NoError(t, err)
+
+	assert.True(t, p.Access(Identity{Kind: KindUnix, Uid: 6000, Gid: 6000}).Allows(RoleOperator, ""))
+	assert.False(t, p.Access(Identity{Kind: KindAnonymous}).Allows(RoleAdmin, ""))
+}
+
+func TestPolicy_TCPWithoutConfig(t *testing.T) {
+	p, err := NewPolicy(nil, nil, nil, "")
+	require.NoError(t, err)
+
+	authenticator := NewAuthenticator(nil, false)
+	start := func(ip net.IP) error {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: ip, Port: 1234},
+		})
+
+		_, err := authenticator.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req any) (any, error) {
+			return p.authorize(ctx, "/svctl.Servers/Start", "/srv/pr1")
+		})
+		return err
+	}
+
+	// Nobody could start servers
+	assert.False(t, p.RemoteOperators())
+	assert.Equal(t, codes.PermissionDenied, status.Code(start(net.IPv4(127, 0, 0, 1))))
+
+	// Unless loopback callers are trusted, like without peer credentials
+	p.TrustLoopback()
+	assert.True(t, p.RemoteOperators())
+	assert.NoError(t, start(net.IPv4(127, 0, 0, 1)))
+	assert.NoError(t, start(net.IPv6loopback))
+	assert.Equal(t, codes.PermissionDenied, status.Code(start(net.IPv4(10, 0, 0, 1))))
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	p, err := NewPolicy(nil, nil, []RoleBinding{
+		{Role: RoleOperator, Certs: []string{"moderator"}},
+	}, "")
+	require.NoError(t, err)
+
+	ctx := NewContext(context.Background(), Identity{Kind: KindCert, Name: "moderator"})
+
+	authCtx, err := p.authorize(ctx, "/svctl.Servers/Restart", "/srv/pr1")
+	require.NoError(t, err)
+	assert.True(t, CanView(authCtx, "/srv/pr1"))
+
+	_, err = p.authorize(ctx, "/svctl.Servers/Unregister", "/srv/pr1")
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = p.authorize(context.Background(), "/svctl.Servers/ListServers", "")
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
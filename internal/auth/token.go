@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+
+	"google.golang.org/grpc/credentials"
+)
+
+const tokenHashPrefix = "sha256:"
+
+// Token is a named bearer token. Only its hash is stored.
+type Token struct {
+	Name string `yaml:"name"`
+	Hash string `yaml:"hash"`
+}
+
+// GenerateToken returns a new random token and its hash.
+func GenerateToken() (token, hash string, err error) {
+	b := make([]byte, 32)
+
+	_, err = rand.Read(b)
+	if err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(b)
+
+	return token, HashToken(token), nil
+}
+
+// HashToken hashes a token for storage. Tokens are random, so a plain hash
+// is enough to keep them from being recovered.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return tokenHashPrefix + hex.EncodeToString(sum[:])
+}
+
+func (t Token) Matches(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(t.Hash), []byte(HashToken(token))) == 1
+}
+
+type tokenCredentials struct {
+	token string
+}
+
+// TokenCredentials returns per-RPC credentials sending token as a bearer
+// token. They are only sent over TLS.
+func TokenCredentials(token string) credentials.PerRPCCredentials {
+	return &tokenCredentials{token: token}
+}
+
+func (c *tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + c.token,
+	}, nil
+}
+
+func (c *tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,160 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/sboon-gg/svctl/internal/audit"
+	"github.com/sboon-gg/svctl/internal/auth"
+)
+
+const (
+	configFile = "daemon.yaml"
+
+	defaultStartDelay      = 10 * time.Second
+	defaultShutdownTimeout = time.Minute
+)
+
+// SocketConfig sets the ownership and permissions of the Unix socket.
+// Whoever can open the socket can query servers.
+type SocketConfig struct {
+	Owner string `yaml:"owner,omitempty"`
+	Group string `yaml:"group,omitempty"`
+	// Mode is an octal permission string like "0660"
+	Mode string `yaml:"mode,omitempty"`
+}
+
+// AllowConfig lists the users and groups, by name or id, allowed to change
+// servers through the Unix socket in addition to root and the daemon user.
+type AllowConfig struct {
+	Users  []string `yaml:"users,omitempty"`
+	Groups []string `yaml:"groups,omitempty"`
+}
+
+// TLSConfig enables TLS for tcp:// endpoints. Setting ClientCA requires
+// clients to present a certificate signed by it.
+type TLSConfig struct {
+	Cert     string `yaml:"cert"`
+	Key      string `yaml:"key"`
+	ClientCA string `yaml:"client_ca,omitempty"`
+}
+
+type ShutdownPolicy string
+
+const (
+	// ShutdownDetach leaves servers running, the next daemon adopts them
+	ShutdownDetach ShutdownPolicy = "detach"
+	// ShutdownStop stops all servers gracefully
+	ShutdownStop ShutdownPolicy = "stop"
+)
+
+// ShutdownConfig sets what happens to the servers when the daemon exits.
+// Timeout bounds stopping all of them.
+type ShutdownConfig struct {
+	Policy  ShutdownPolicy `yaml:"policy,omitempty"`
+	Timeout time.Duration  `yaml:"timeout,omitempty"`
+}
+
+// AuditConfig limits the audit log: it is rotated once it reaches MaxSizeMB
+// and MaxFiles rotated files are kept, all of them unless set.
+type AuditConfig struct {
+	MaxSizeMB int `yaml:"max_size_mb,omitempty"`
+	MaxFiles  int `yaml:"max_files,omitempty"`
+}
+
+// Log returns the config of the audit log.
+func (c AuditConfig) Log() audit.Config {
+	config := audit.Config{
+		MaxSize:  audit.DefaultMaxSize,
+		MaxFiles: c.MaxFiles,
+	}
+
+	if c.MaxSizeMB > 0 {
+		config.MaxSize = int64(c.MaxSizeMB) << 20
+	}
+
+	return config
+}
+
+// Config is the daemon configuration, read from daemon.yaml in the user
+// config directory unless given explicitly.
+type Config struct {
+	// Listen is the endpoint to serve the API on, tcp://host:port or
+	// unix:///path.sock
+	Listen string       `yaml:"listen,omitempty"`
+	Socket SocketConfig `yaml:"socket,omitempty"`
+	Allow  AllowConfig  `yaml:"allow,omitempty"`
+	TLS    *TLSConfig   `yaml:"tls,omitempty"`
+	// Tokens accepted as bearer tokens over TLS. Configuring any makes
+	// authentication mandatory for tcp:// clients.
+	Tokens []auth.Token `yaml:"tokens,omitempty"`
+	// Roles grant access to callers other than root, the daemon user and
+	// the allowed users and groups.
+	Roles []auth.RoleBinding `yaml:"roles,omitempty"`
+	// DefaultRole applies to callers without a role binding
+	DefaultRole auth.Role `yaml:"default_role,omitempty"`
+	// CacheDir holds the daemon state. Daemons running side by side need
+	// their own.
+	CacheDir string `yaml:"cache_dir,omitempty"`
+	// StartDelay staggers starting servers when the daemon boots
+	StartDelay time.Duration  `yaml:"start_delay,omitempty"`
+	Shutdown   ShutdownConfig `yaml:"shutdown,omitempty"`
+	Audit      AuditConfig    `yaml:"audit,omitempty"`
+}
+
+func DefaultConfigPath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, svctlDir, configFile), nil
+}
+
+// ReadConfig reads the config at path. A missing file is not an error, the
+// defaults are returned instead.
+func ReadConfig(path string) (*Config, error) {
+	config := &Config{}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	if err == nil {
+		err = yaml.Unmarshal(content, config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Listen == "" {
+		config.Listen = DefaultListen()
+	}
+
+	if config.StartDelay <= 0 {
+		config.StartDelay = defaultStartDelay
+	}
+
+	switch config.Shutdown.Policy {
+	case "":
+		config.Shutdown.Policy = ShutdownDetach
+	case ShutdownDetach, ShutdownStop:
+	default:
+		return nil, fmt.Errorf("invalid shutdown policy %q", config.Shutdown.Policy)
+	}
+
+	if config.Shutdown.Timeout <= 0 {
+		config.Shutdown.Timeout = defaultShutdownTimeout
+	}
+
+	if config.Audit.MaxSizeMB < 0 || config.Audit.MaxFiles < 0 {
+		return nil, errors.New("audit limits must not be negative")
+	}
+
+	return config, nil
+}
@@ -1,119 +1,405 @@
 package daemon
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/fsnotify/fsnotify"
+	"github.com/sboon-gg/svctl/internal/audit"
 	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/persist"
+	"github.com/sboon-gg/svctl/internal/scheduler"
 	"github.com/sboon-gg/svctl/pkg/prbf2update"
 )
 
 const (
 	svctlDir  = "svctl"
 	stateFile = "state.yaml"
+	auditFile = "audit.log"
+)
+
+var (
+	ErrNotFound      = errors.New("server not found")
+	ErrAlreadyExists = errors.New("server already registered")
+	ErrInvalidPath   = errors.New("invalid server path")
 )
 
 type Daemon struct {
 	cacheDir     string
-	Servers      map[string]*fsm.FSM
 	updaterCache *prbf2update.Cache
+	scheduler    *scheduler.Scheduler
+	audit        *audit.Log
+	lock         *persist.FileLock
+	// Nil when settings cannot be watched
+	watcher *fsnotify.Watcher
+	// Guards watches, what is watched for each server
+	watchMu sync.Mutex
+	watches map[string]*settingsWatch
+
+	mu      sync.RWMutex
+	Servers map[string]*fsm.FSM
+	// followers follow the events of all servers
+	followers map[*follower]struct{}
+
+	// Guards state and saving it
+	stateMu sync.Mutex
+	state   *State
 }
 
-func New() (*Daemon, error) {
-	cacheDir, err := os.UserCacheDir()
+// New creates a daemon keeping its state in cacheDir, or in the user cache
+// directory when cacheDir is empty. It fails with ErrRunning when another
+// daemon uses the same directory. Close releases it.
+func New(cacheDir string) (*Daemon, error) {
+	svctlCacheDir, err := CacheDir(cacheDir)
 	if err != nil {
 		return nil, err
 	}
 
-	svctlCacheDir := filepath.Join(cacheDir, svctlDir)
-
 	err = os.MkdirAll(svctlCacheDir, 0755)
 	if err != nil {
 		return nil, err
 	}
 
+	lock, err := lockCacheDir(svctlCacheDir)
+	if err != nil {
+		return nil, err
+	}
+
 	updaterCacheDir := filepath.Join(svctlCacheDir, "updater")
 
 	err = os.MkdirAll(updaterCacheDir, 0755)
 	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	state, err := readState(filepath.Join(svctlCacheDir, stateFile))
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	auditLog, err := audit.Open(filepath.Join(svctlCacheDir, auditFile))
+	if err != nil {
+		lock.Unlock()
 		return nil, err
 	}
 
 	return &Daemon{
 		Servers:      make(map[string]*fsm.FSM),
+		followers:    make(map[*follower]struct{}),
 		cacheDir:     svctlCacheDir,
 		updaterCache: prbf2update.NewCache(updaterCacheDir),
+		scheduler:    scheduler.New(),
+		audit:        auditLog,
+		lock:         lock,
+		watcher:      newWatcher(),
+		watches:      make(map[string]*settingsWatch),
+		state:        state,
 	}, nil
 }
 
-func Recover() (*Daemon, error) {
-	d, err := New()
+func Recover(cacheDir string) (*Daemon, error) {
+	d, err := New(cacheDir)
 	if err != nil {
 		return nil, err
 	}
 
-	state, err := d.State()
-	if err != nil {
-		return nil, err
-	}
-
-	for _, svPath := range state.Servers {
+	for _, svPath := range d.State().Servers {
 		s, err := OpenServer(svPath, d.updaterCache)
 		if err != nil {
+			d.Close()
 			return nil, err
 		}
 
 		d.Servers[svPath] = s
+		d.schedule(svPath, s)
+		d.watchSettings(svPath, s)
 	}
 
 	return d, nil
 }
 
 func (s *Daemon) Register(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !filepath.IsAbs(path) {
+		return fmt.Errorf("%w %q: must be absolute", ErrInvalidPath, path)
+	}
+
 	if _, ok := s.Servers[path]; ok {
-		return fmt.Errorf("server %q already exists", path)
+		return fmt.Errorf("%w: %q", ErrAlreadyExists, path)
 	}
 
 	sv, err := OpenServer(path, s.updaterCache)
 	if err != nil {
-		return err
+		return fmt.Errorf("%w %q: %w", ErrInvalidPath, path, err)
 	}
 
 	s.Servers[path] = sv
+	for f := range s.followers {
+		f.attach(sv)
+	}
+	s.schedule(path, sv)
+	s.watchSettings(path, sv)
+
+	return s.updateState(func(state *State) {
+		state.Servers = append(state.Servers, path)
+	})
+}
+
+// Unregister stops the server on path, whatever state it is in, and stops
+// supervising it. It waits for the server to be stopped, up to ctx.
+func (s *Daemon) Unregister(ctx context.Context, path string) error {
+	srv, err := s.findServer(path)
+	if err != nil {
+		return err
+	}
 
-	state, err := s.State()
+	err = stopServer(ctx, srv)
 	if err != nil {
 		return err
 	}
 
-	state.Servers = append(state.Servers, path)
+	s.scheduler.RemoveGroup(path)
+	s.unwatchSettings(path)
+	srv.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.Servers, path)
+
+	return s.updateState(func(state *State) {
+		state.Servers = slices.DeleteFunc(state.Servers, func(p string) bool {
+			return p == path
+		})
+		delete(state.Desired, path)
+	})
+}
 
-	return s.SaveState(state)
+// Start starts the server on path. With wait it blocks until the server is
+// Running or failed to start.
+func (s *Daemon) Start(ctx context.Context, path string, wait bool) error {
+	return s.act(ctx, path, wait, fsm.StateTRunning, (*fsm.FSM).Start)
 }
 
-func (s *Daemon) Start(path string) error {
+func (s *Daemon) Stop(ctx context.Context, path string, wait bool) error {
+	return s.act(ctx, path, wait, fsm.StateTStopped, (*fsm.FSM).Stop)
+}
+
+func (s *Daemon) Restart(ctx context.Context, path string, wait bool) error {
+	return s.act(ctx, path, wait, fsm.StateTRunning, (*fsm.FSM).Restart)
+}
+
+func (s *Daemon) Reset(ctx context.Context, path string, wait bool) error {
+	return s.act(ctx, path, wait, fsm.StateTRunning, (*fsm.FSM).Reset)
+}
+
+func (s *Daemon) act(ctx context.Context, path string, wait bool, target fsm.StateT, action func(*fsm.FSM) error) error {
 	srv, err := s.findServer(path)
 	if err != nil {
 		return err
 	}
 
-	return srv.Start()
+	act := func() error {
+		err := action(srv)
+		if err != nil {
+			return err
+		}
+
+		s.setRunning(path, target == fsm.StateTRunning)
+		return nil
+	}
+
+	if !wait {
+		return act()
+	}
+
+	return srv.Wait(ctx, target, act)
+}
+
+// Reconcile starts the servers that should be running after the daemon
+// booted, delay apart so they don't all load at once. Servers that were
+// adopted are left alone.
+func (s *Daemon) Reconcile(ctx context.Context, delay time.Duration) {
+	state := s.State()
+	started := false
+
+	for _, path := range state.Servers {
+		if !state.Desired[path].ShouldRun() {
+			continue
+		}
+
+		srv, err := s.findServer(path)
+		if err != nil || srv.State() != fsm.StateTStopped {
+			continue
+		}
+
+		if started {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		log := srv.Server().Settings.Log
+
+		log.Info("Starting server to reconcile desired state")
+		err = srv.Start()
+		if errors.Is(err, fsm.ErrActionNotAllowed) {
+			// Adopted or started by someone else meanwhile
+			continue
+		}
+		if err != nil {
+			log.Error("Failed to start server", "error", err.Error())
+			continue
+		}
+
+		started = true
+	}
+}
+
+func (s *Daemon) Status(path string) (*fsm.Status, error) {
+	srv, err := s.findServer(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return srv.Status(), nil
 }
 
-func (s *Daemon) Stop(path string) error {
+// Output returns up to the last lines the server on path printed, all
+// lines kept when lines is not positive.
+func (s *Daemon) Output(path string, lines int) ([]string, error) {
 	srv, err := s.findServer(path)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	return srv.Output(lines), nil
+}
+
+// Close stops supervising the servers, leaving their processes running, and
+// releases the cache dir for the next daemon.
+func (s *Daemon) Close() {
+	s.scheduler.Close()
+
+	if s.watcher != nil {
+		_ = s.watcher.Close()
+	}
+
+	s.mu.Lock()
+	for _, srv := range s.Servers {
+		srv.Close()
+	}
+	s.mu.Unlock()
+
+	err := s.audit.Close()
+	if err != nil {
+		slog.Error("Failed to close audit log", "error", err.Error())
+	}
+
+	s.unlockCacheDir()
+}
+
+// Audit returns the log of API calls.
+func (s *Daemon) Audit() *audit.Log {
+	return s.audit
+}
+
+// Paths returns the paths of all registered servers in a stable order.
+func (s *Daemon) Paths() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	paths := make([]string, 0, len(s.Servers))
+	for path := range s.Servers {
+		paths = append(paths, path)
 	}
-	return srv.Stop()
+
+	sort.Strings(paths)
+
+	return paths
 }
 
 func (d *Daemon) findServer(path string) (*fsm.FSM, error) {
+	if path == "" {
+		return nil, fmt.Errorf("%w: path must not be empty", ErrInvalidPath)
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
 	s, ok := d.Servers[path]
 	if !ok {
-		return nil, fmt.Errorf("server %q not found", path)
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, path)
 	}
 
 	return s, nil
 }
+
+// Alive checks that the state machine of every server is still responsive,
+// unregistered servers aside.
+func (d *Daemon) Alive(ctx context.Context) error {
+	d.mu.RLock()
+	servers := make([]*fsm.FSM, 0, len(d.Servers))
+	for _, srv := range d.Servers {
+		servers = append(servers, srv)
+	}
+	d.mu.RUnlock()
+
+	for _, srv := range servers {
+		err := srv.Ping(ctx)
+		if err != nil && !errors.Is(err, fsm.ErrClosed) {
+			return fmt.Errorf("server %s is not responding: %w", srv.Server().Path, err)
+		}
+	}
+
+	return nil
+}
+
+// Summary describes how many servers are in which state, like
+// "3 servers: 2 Running, 1 Stopped".
+func (s *Daemon) Summary() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.Servers) == 0 {
+		return "no servers registered"
+	}
+
+	counts := map[string]int{}
+	for _, srv := range s.Servers {
+		counts[srv.State().String()]++
+	}
+
+	states := make([]string, 0, len(counts))
+	for state := range counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+
+	parts := make([]string, 0, len(states))
+	for _, state := range states {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[state], state))
+	}
+
+	noun := "servers"
+	if len(s.Servers) == 1 {
+		noun = "server"
+	}
+
+	return fmt.Sprintf("%d %s: %s", len(s.Servers), noun, strings.Join(parts, ", "))
+}
@@ -0,0 +1,107 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemon_UnregisterStarting(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(d.Close)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Never ready, so it stays Starting
+	path := newTestServerWith(t, "#!/bin/sh\nsleep 1000\n", fmt.Sprintf("loggers: []\nready:\n  tcp: %s\n", addr))
+	require.NoError(t, d.Register(path))
+
+	srv, err := d.findServer(path)
+	require.NoError(t, err)
+	require.NoError(t, srv.Wait(ctx, fsm.StateTStarting, srv.Start))
+
+	pid := srv.Pid()
+	t.Cleanup(func() { _ = syscall.Kill(-pid, syscall.SIGKILL) })
+
+	require.NoError(t, d.Unregister(ctx, path))
+
+	assert.Empty(t, d.Paths())
+	assert.Eventually(t, func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	// Stopped servers are unregistered too
+	path = newTestServer(t)
+	require.NoError(t, d.Register(path))
+	require.NoError(t, d.Unregister(ctx, path))
+}
+
+func waitRunning(t *testing.T, events <-chan fsm.Event) {
+	t.Helper()
+
+	for {
+		select {
+		case e, ok := <-events:
+			require.True(t, ok, "event stream closed")
+			if e.Type == fsm.EventTransition && e.To == fsm.StateTRunning {
+				return
+			}
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "server not running")
+		}
+	}
+}
+
+func TestDaemon_SubscribeAll(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	followCtx, stopFollowing := context.WithCancel(ctx)
+
+	// No server is registered yet
+	history, events, err := d.Subscribe(followCtx, "")
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	path := newTestServer(t)
+	require.NoError(t, d.Register(path))
+	require.NoError(t, d.Start(ctx, path, false))
+
+	waitRunning(t, events)
+
+	// Unregistered servers are dropped from the stream
+	require.NoError(t, d.Unregister(ctx, path))
+
+	other := newTestServer(t)
+	require.NoError(t, d.Register(other))
+	require.NoError(t, d.Start(ctx, other, false))
+
+	waitRunning(t, events)
+
+	stopFollowing()
+	for range events {
+	}
+}
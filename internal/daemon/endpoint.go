@@ -0,0 +1,169 @@
+package daemon
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const defaultSocketMode = 0660
+
+// SystemSocket is the default socket of a daemon run by root outside of a
+// login session, like a system service on Linux.
+const SystemSocket = "/run/svctl/svctl.sock"
+
+// Endpoint is an address the daemon API is served on.
+type Endpoint struct {
+	Network string
+	Address string
+}
+
+// ParseEndpoint parses tcp://host:port or unix:///path.sock.
+func ParseEndpoint(s string) (Endpoint, error) {
+	scheme, address, ok := strings.Cut(s, "://")
+	if !ok {
+		return Endpoint{}, fmt.Errorf("invalid endpoint %q: expected tcp://host:port or unix:///path.sock", s)
+	}
+
+	switch scheme {
+	case "tcp":
+		_, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return Endpoint{}, fmt.Errorf("invalid endpoint %q: %w", s, err)
+		}
+	case "unix":
+		if address == "" {
+			return Endpoint{}, fmt.Errorf("invalid endpoint %q: missing socket path", s)
+		}
+
+		var err error
+		address, err = filepath.Abs(address)
+		if err != nil {
+			return Endpoint{}, err
+		}
+	default:
+		return Endpoint{}, fmt.Errorf("invalid endpoint %q: unsupported scheme %q", s, scheme)
+	}
+
+	return Endpoint{
+		Network: scheme,
+		Address: address,
+	}, nil
+}
+
+func (e Endpoint) String() string {
+	return e.Network + "://" + e.Address
+}
+
+// Target returns the endpoint in the form expected by grpc.Dial.
+func (e Endpoint) Target() string {
+	if e.Network == "unix" {
+		return "unix://" + e.Address
+	}
+
+	return e.Address
+}
+
+// Listen listens on the endpoint. Unix sockets get the ownership and mode
+// of socket, a socket file left behind by a daemon that is no longer
+// running is removed first.
+func (e Endpoint) Listen(socket SocketConfig) (net.Listener, error) {
+	if e.Network != "unix" {
+		return net.Listen(e.Network, e.Address)
+	}
+
+	if !unixSocketsSupported {
+		return nil, fmt.Errorf("unix sockets are only supported on Linux, where callers can be identified")
+	}
+
+	err := socketDir(filepath.Dir(e.Address))
+	if err != nil {
+		return nil, err
+	}
+
+	err = removeStaleSocket(e.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	lis, err := net.Listen(e.Network, e.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	err = applySocketConfig(e.Address, socket)
+	if err != nil {
+		lis.Close()
+		return nil, err
+	}
+
+	return lis, nil
+}
+
+func applySocketConfig(path string, socket SocketConfig) error {
+	mode := uint64(defaultSocketMode)
+	if socket.Mode != "" {
+		var err error
+		mode, err = strconv.ParseUint(socket.Mode, 8, 32)
+		if err != nil {
+			return fmt.Errorf("invalid socket mode %q: %w", socket.Mode, err)
+		}
+	}
+
+	err := os.Chmod(path, os.FileMode(mode))
+	if err != nil {
+		return err
+	}
+
+	if socket.Owner == "" && socket.Group == "" {
+		return nil
+	}
+
+	uid, gid := -1, -1
+
+	if socket.Owner != "" {
+		u, err := user.Lookup(socket.Owner)
+		if err != nil {
+			return fmt.Errorf("socket owner: %w", err)
+		}
+
+		uid, err = strconv.Atoi(u.Uid)
+		if err != nil {
+			return err
+		}
+	}
+
+	if socket.Group != "" {
+		g, err := user.LookupGroup(socket.Group)
+		if err != nil {
+			return fmt.Errorf("socket group: %w", err)
+		}
+
+		gid, err = strconv.Atoi(g.Gid)
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.Chown(path, uid, gid)
+}
+
+func removeStaleSocket(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil || fi.Mode()&os.ModeSocket == 0 {
+		// Let Listen report anything that is not a socket
+		return nil
+	}
+
+	conn, err := net.Dial("unix", path)
+	if err == nil {
+		conn.Close()
+		return fmt.Errorf("socket %s is in use by another daemon", path)
+	}
+
+	return os.Remove(path)
+}
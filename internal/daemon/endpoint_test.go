@@ -0,0 +1,39 @@
+package daemon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	tests := []struct {
+		in      string
+		network string
+		target  string
+		wantErr bool
+	}{
+		{in: "tcp://localhost:50051", network: "tcp", target: "localhost:50051"},
+		{in: "tcp://:50051", network: "tcp", target: ":50051"},
+		{in: "unix:///run/svctl.sock", network: "unix", target: "unix:///run/svctl.sock"},
+		{in: "localhost:50051", wantErr: true},
+		{in: "tcp://localhost", wantErr: true},
+		{in: "unix://", wantErr: true},
+		{in: "http://localhost:50051", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			e, err := ParseEndpoint(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.network, e.Network)
+			assert.Equal(t, tt.target, e.Target())
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+)
+
+// follower is notified of servers registered while it follows all servers.
+type follower struct {
+	attach func(*fsm.FSM)
+}
+
+// Subscribe returns recent events of the server on path, or of all servers
+// when path is empty, followed by a channel of new events. Following all
+// servers includes those registered later, the channel is closed once ctx
+// is done. Following one server ends when it is unregistered too.
+func (s *Daemon) Subscribe(ctx context.Context, path string) ([]fsm.Event, <-chan fsm.Event, error) {
+	out := make(chan fsm.Event)
+
+	var (
+		history []fsm.Event
+		wg      sync.WaitGroup
+	)
+
+	attach := func(srv *fsm.FSM) []fsm.Event {
+		h, events, unsubscribe := srv.Subscribe()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer unsubscribe()
+
+			forward(ctx, events, out)
+		}()
+
+		return h
+	}
+
+	if path != "" {
+		srv, err := s.findServer(path)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		history = attach(srv)
+
+		go func() {
+			wg.Wait()
+			close(out)
+		}()
+
+		return history, out, nil
+	}
+
+	f := &follower{
+		attach: func(srv *fsm.FSM) {
+			attach(srv)
+		},
+	}
+
+	// Servers are attached under the lock Register holds, so none is missed
+	// or attached twice
+	s.mu.Lock()
+	for _, srv := range s.Servers {
+		history = append(history, attach(srv)...)
+	}
+	s.followers[f] = struct{}{}
+	s.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		s.mu.Lock()
+		delete(s.followers, f)
+		s.mu.Unlock()
+
+		wg.Wait()
+		close(out)
+	}()
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Time.Before(history[j].Time)
+	})
+
+	return history, out, nil
+}
+
+// forward sends events to out until ctx is done or the server is closed.
+func forward(ctx context.Context, events <-chan fsm.Event, out chan<- fsm.Event) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
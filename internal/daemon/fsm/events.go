@@ -0,0 +1,125 @@
+package fsm
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	eventHistorySize     = 100
+	subscriberBufferSize = 64
+)
+
+type Event struct {
+	Type   EventType
+	Path   string
+	Time   time.Time
+	From   StateT
+	To     StateT
+	Reason string
+	Pid    int
+	Err    error
+}
+
+// broker fans out FSM events to subscribers and keeps a short history
+// for clients that connect after the fact.
+type broker struct {
+	mu          sync.Mutex
+	history     []Event
+	subscribers map[chan Event]struct{}
+	closed      bool
+}
+
+func newBroker() *broker {
+	return &broker{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+func (b *broker) publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	b.history = append(b.history, e)
+	if len(b.history) > eventHistorySize {
+		b.history = b.history[len(b.history)-eventHistorySize:]
+	}
+
+	for ch := range b.subscribers {
+		// Never block the state machine on a slow subscriber
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+func (b *broker) subscribe() ([]Event, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, subscriberBufferSize)
+
+	history := make([]Event, len(b.history))
+	copy(history, b.history)
+
+	// Nothing is published anymore, so the channel ends right away
+	if b.closed {
+		close(ch)
+		return history, ch, func() {}
+	}
+
+	b.subscribers[ch] = struct{}{}
+
+	unsubscribe := func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		// Already closed by close otherwise
+		if _, ok := b.subscribers[ch]; ok {
+			delete(b.subscribers, ch)
+			close(ch)
+		}
+	}
+
+	return history, ch, unsubscribe
+}
+
+// close closes the channels of all subscribers, nothing is published after.
+func (b *broker) close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	for ch := range b.subscribers {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Subscribe returns the recent event history and a channel receiving
+// every event published afterwards until the FSM is closed. Call unsubscribe
+// to release the channel.
+func (fsm *FSM) Subscribe() (history []Event, events <-chan Event, unsubscribe func()) {
+	return fsm.events.subscribe()
+}
+
+// Emit publishes an event on behalf of another component, like the scheduler.
+func (fsm *FSM) Emit(e Event) {
+	fsm.emit(e)
+}
+
+func (fsm *FSM) emit(e Event) {
+	e.Path = fsm.server.Path
+	e.Time = time.Now()
+	if e.Pid == 0 {
+		e.Pid = fsm.Pid()
+	}
+
+	fsm.events.publish(e)
+}
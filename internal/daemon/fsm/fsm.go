@@ -5,174 +5,731 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/sboon-gg/svctl/internal/server"
+	"github.com/sboon-gg/svctl/internal/serverlog"
+	"github.com/sboon-gg/svctl/internal/settings"
 	"github.com/sboon-gg/svctl/pkg/prbf2proc"
 	"github.com/sboon-gg/svctl/pkg/prbf2update"
 )
 
-var ErrActionNotAllowed = errors.New("action not allowed")
+var (
+	ErrActionNotAllowed = errors.New("action not allowed")
+	ErrClosed           = errors.New("state machine closed")
+	ErrNotReached       = errors.New("server did not reach")
+)
+
+// ActionError is returned for an action not allowed in the current state.
+// It matches ErrActionNotAllowed.
+type ActionError struct {
+	Action  Action
+	State   StateT
+	Allowed []Action
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("%s not allowed in state %s", e.Action, e.State)
+}
+
+func (e *ActionError) Is(target error) bool {
+	return target == ErrActionNotAllowed
+}
+
+const renderInterval = time.Minute
 
 type State interface {
 	Enter(*FSM)
-	Exit()
+	Exit(*FSM)
 }
 
-type FSM struct {
-	states         map[StateT]State
-	allowedActions map[Action][]State
+type updater interface {
+	CurrentVersion() (string, error)
+	IsNewVersionAvailable() (bool, error)
+	Update() (*prbf2update.Result, error)
+}
 
-	currentState State
-	desiredState State
+// request is an action sent to the FSM goroutine.
+type request struct {
+	action Action
+	proc   *os.Process
+	result chan error
+}
 
-	server *server.Server
+// transition describes a pending change of state and why it was requested.
+type transition struct {
+	to     StateT
+	reason string
+	err    error
+}
+
+// FSM supervises a single server. All state changes happen on one goroutine
+// that reacts to requested actions, process exits and timers.
+type FSM struct {
+	states map[StateT]State
+	// actions maps each action to the states it is allowed in and the state
+	// it leads to from there
+	actions map[Action]map[StateT]StateT
 
-	proc    *prbf2proc.PRBF2Process
-	updater *prbf2update.PRBF2Update
+	server *server.Server
 
-	err error
+	proc     *prbf2proc.PRBF2Process
+	updater  updater
+	restarts *restarter
+	// ready holds the readiness conditions, zero when a started process is
+	// Running right away
+	ready  settings.ReadyConfig
+	output *serverlog.Log
+
+	// Owned by the FSM goroutine
+	current      StateT
+	pending      *transition
+	exited       <-chan struct{}
+	renderTicker *time.Ticker
+	restartTimer *time.Timer
+	exitErr      error
+	adoptee      *os.Process
+	resetting    bool
+	probeResult  <-chan error
+	cancelProbe  func()
+	job          *job
+	// stopAfterUpdate leaves the server stopped once Updating is done
+	stopAfterUpdate bool
+	// The process last recorded in the cache
+	storedPid      int
+	storedIdentity *prbf2proc.Identity
+
+	// Guards the fields below, which are read by Status from other goroutines
+	mu          sync.RWMutex
+	state       StateT
+	err         error
+	startedAt   time.Time
+	nextRestart time.Time
+
+	events *broker
+
+	requests  chan request
+	pings     chan struct{}
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
 
-	cancel context.CancelFunc
+// Status is a point-in-time snapshot of a managed server.
+type Status struct {
+	State       StateT
+	Pid         int
+	Uptime      time.Duration
+	Restarts    int
+	NextRestart time.Time
+	Err         error
+	Version     string
 }
 
 func New(sv *server.Server, updateCache *prbf2update.Cache) *FSM {
+	return newFSM(sv, prbf2update.New(sv.Path, updateCache))
+}
+
+func newFSM(sv *server.Server, u updater) *FSM {
 	states := map[StateT]State{
-		StateTStopped:    &StateStopped{},
-		StateTRunning:    &StateRunning{},
-		StateTRestarting: &StateRestarting{},
+		StateTStopped:       &StateStopped{},
+		StateTStarting:      &StateStarting{},
+		StateTAdopting:      &StateAdopting{},
+		StateTRunning:       &StateRunning{},
+		StateTStopping:      &StateStopping{},
+		StateTRestarting:    &StateRestarting{},
+		StateTExited:        &StateExited{},
+		StateTUpdating:      &StateUpdating{},
+		StateTErrored:       &StateErrored{},
+		StateTCleaningError: &StateCleaningError{},
 	}
 
-	allowedActions := map[Action][]State{
+	actions := map[Action]map[StateT]StateT{
 		ActionStart: {
-			states[StateTStopped],
+			StateTStopped: StateTStarting,
 		},
 		ActionStop: {
-			states[StateTRunning],
+			StateTStarting:   StateTStopping,
+			StateTRunning:    StateTStopping,
+			StateTStopping:   StateTStopping,
+			StateTRestarting: StateTStopping,
+			StateTExited:     StateTStopping,
+			// The update is finished first
+			StateTUpdating: StateTUpdating,
+			StateTErrored:  StateTCleaningError,
 		},
 		ActionRestart: {
-			states[StateTRunning],
+			StateTRunning: StateTRestarting,
+			StateTExited:  StateTRestarting,
 		},
 		ActionAdopt: {
-			states[StateTStopped],
+			StateTStopped: StateTAdopting,
+		},
+		ActionReset: {
+			StateTErrored: StateTCleaningError,
 		},
 	}
 
+	procOpts, err := sv.ProcessOptions()
+	if err != nil {
+		sv.Settings.Log.Error("Invalid process config, using defaults", "error", err.Error())
+	}
+
 	// Ignore error since we know the path is valid
-	proc, _ := prbf2proc.New(sv.Path)
+	proc, _ := prbf2proc.New(sv.Path, procOpts...)
 
-	return &FSM{
-		states:         states,
-		allowedActions: allowedActions,
-		currentState:   states[StateTStopped],
-		desiredState:   states[StateTStopped],
-		server:         sv,
-		proc:           proc,
-		updater:        prbf2update.New(sv.Path, updateCache),
+	restartConfig, err := restartConfig(sv)
+	if err != nil {
+		sv.Settings.Log.Error("Invalid restart config, using defaults", "error", err.Error())
 	}
-}
 
-func (fsm *FSM) loop() {
-	if fsm.cancel != nil {
-		fsm.cancel()
+	ready, err := readyConfig(sv)
+	if err != nil {
+		sv.Settings.Log.Error("Invalid ready config, ignoring it", "error", err.Error())
+	}
+
+	outputConfig, err := outputConfig(sv)
+	if err != nil {
+		sv.Settings.Log.Error("Invalid output config, using defaults", "error", err.Error())
+	}
+
+	output := serverlog.New(filepath.Join(sv.Settings.Path(), settings.LogsDir), outputConfig, func(err error) {
+		sv.Settings.Log.Error("Failed to write server output", "error", err.Error())
+	})
+
+	fsm := &FSM{
+		states:    states,
+		actions:   actions,
+		current:   StateTStopped,
+		state:     StateTStopped,
+		storedPid: -1,
+		server:    sv,
+		proc:      proc,
+		updater:   u,
+		restarts:  newRestarter(restartConfig),
+		ready:     ready,
+		output:    output,
+		events:    newBroker(),
+		requests:  make(chan request),
+		pings:     make(chan struct{}),
+		quit:      make(chan struct{}),
+		done:      make(chan struct{}),
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	fsm.cancel = cancel
+	go fsm.loop()
+
+	return fsm
+}
+
+func (fsm *FSM) loop() {
+	defer close(fsm.done)
 
 	for {
 		select {
-		case <-ctx.Done():
+		case <-fsm.quit:
+			fsm.stopProbe()
+			fsm.stopJob()
+
+			// Left running for the next daemon
+			switch fsm.current {
+			case StateTStarting, StateTRunning, StateTStopping, StateTRestarting:
+				if fsm.proc.Pid() != -1 {
+					fsm.storeProcess(fsm.server.Settings.Log)
+				}
+			}
 			return
-		default:
-			fsm.Transition()
+		case req := <-fsm.requests:
+			req.result <- fsm.handle(req)
+		case <-fsm.pings:
+		case <-fsm.exited:
+			fsm.exited = nil
+			fsm.exitErr = fsm.proc.ExitErr()
+			fsm.server.Settings.Log.Debug("Process exited")
+
+			switch fsm.current {
+			case StateTRunning:
+				fsm.changeState(StateTExited, "process exited")
+			case StateTStarting:
+				fsm.changeState(StateTExited, "process exited before it was ready")
+			}
+		case err := <-fsm.probeResult:
+			fsm.stopProbe()
+
+			if err != nil {
+				fsm.handleError(err)
+			} else {
+				fsm.changeState(StateTRunning, "process ready")
+			}
+		case err := <-fsm.jobC():
+			fsm.finishJob(err)
+		case <-fsm.restartC():
+			fsm.restartTimer = nil
+			fsm.continueRestart()
+		case <-fsm.renderC():
+			err := fsm.render()
+			if err != nil {
+				fsm.server.Settings.Log.Error(errors.Join(errors.New("Failed to render templates"), err).Error())
+			}
+
+			fsm.storeProcess(fsm.server.Settings.Log)
 		}
+
+		fsm.transition()
 	}
 }
 
+// Close stops the FSM goroutine and closes the channels of subscribers.
+// The managed process is left untouched.
+func (fsm *FSM) Close() {
+	fsm.closeOnce.Do(func() {
+		close(fsm.quit)
+	})
+	<-fsm.done
+
+	fsm.output.Close()
+	fsm.events.close()
+}
+
 func (fsm *FSM) Pid() int {
 	return fsm.proc.Pid()
 }
 
-func (fsm *FSM) Start() error {
-	go fsm.loop()
-	return fsm.action(ActionStart, StateTRunning)
+func (fsm *FSM) State() StateT {
+	fsm.mu.RLock()
+	defer fsm.mu.RUnlock()
+
+	return fsm.state
 }
 
-func (fsm *FSM) Stop() error {
-	err := fsm.action(ActionStop, StateTStopped)
-	if err != nil {
-		return err
+func (fsm *FSM) Status() *Status {
+	fsm.mu.RLock()
+	status := &Status{
+		State:       fsm.state,
+		Err:         fsm.err,
+		NextRestart: fsm.nextRestart,
 	}
+	startedAt := fsm.startedAt
+	fsm.mu.RUnlock()
 
-	time.Sleep(300 * time.Millisecond)
-	fsm.cancel()
+	status.Pid = fsm.Pid()
+	status.Restarts = fsm.restarts.Count()
 
-	return nil
+	if !startedAt.IsZero() {
+		status.Uptime = time.Since(startedAt)
+	}
+
+	// Version is informative only, so a missing mod.desc is not an error here
+	status.Version, _ = fsm.updater.CurrentVersion()
+
+	return status
+}
+
+func (fsm *FSM) Start() error {
+	return fsm.action(request{action: ActionStart})
+}
+
+func (fsm *FSM) Stop() error {
+	return fsm.action(request{action: ActionStop})
 }
 
 func (fsm *FSM) Restart() error {
-	return fsm.action(ActionRestart, StateTRestarting)
+	return fsm.action(request{action: ActionRestart})
 }
 
 func (fsm *FSM) Adopt(proc *os.Process) error {
-	if !fsm.isActionAllowed(ActionAdopt) {
-		return ErrActionNotAllowed
-	}
+	return fsm.action(request{action: ActionAdopt, proc: proc})
+}
+
+// Reset clears the error of an errored server and starts it again.
+func (fsm *FSM) Reset() error {
+	return fsm.action(request{action: ActionReset})
+}
+
+// Render renders the server templates without changing state.
+func (fsm *FSM) Render() error {
+	return fsm.action(request{action: ActionRender})
+}
+
+// Reload reloads the server settings and applies them. A running server
+// keeps running, changed stop, restart and readiness settings apply from
+// then on. The old settings stay in use when the new ones are invalid.
+func (fsm *FSM) Reload() error {
+	return fsm.action(request{action: ActionReload})
+}
+
+// CheckUpdate looks for a new server version in the background, the result
+// is reported as an UpdateChecked event. A running server is restarted to
+// apply it, a stopped one is updated and stays stopped.
+func (fsm *FSM) CheckUpdate() error {
+	return fsm.action(request{action: ActionCheckUpdate})
+}
+
+// Wait runs act and blocks until the server reaches target. It fails with
+// the error that parked the server when it ends up Errored instead, or when
+// it stops before reaching target.
+func (fsm *FSM) Wait(ctx context.Context, target StateT, act func() error) error {
+	// Subscribe first so no transition caused by act is missed
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
 
-	err := fsm.proc.Adopt(proc)
+	err := act()
 	if err != nil {
 		return err
 	}
 
-	go fsm.loop()
-
-	return fsm.Start()
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return ErrClosed
+			}
+			if e.Type != EventTransition {
+				continue
+			}
+
+			switch {
+			case e.To == target:
+				return nil
+			case e.To == StateTErrored:
+				if e.Err != nil {
+					return fmt.Errorf("%w %s: %w", ErrNotReached, target, e.Err)
+				}
+				return fmt.Errorf("%w %s: server errored: %s", ErrNotReached, target, e.Reason)
+			case e.To == StateTStopped:
+				return fmt.Errorf("%w %s: server stopped: %s", ErrNotReached, target, e.Reason)
+			}
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for server to be %s: %w", target, ctx.Err())
+		case <-fsm.done:
+			return ErrClosed
+		}
+	}
 }
 
-func (fsm *FSM) isActionAllowed(action Action) bool {
-	allowedStates, ok := fsm.allowedActions[action]
-	if !ok {
-		return false
+// Ping returns once the FSM goroutine is ready to handle requests, or fails
+// when it doesn't get to it before ctx is done.
+func (fsm *FSM) Ping(ctx context.Context) error {
+	select {
+	case fsm.pings <- struct{}{}:
+		return nil
+	case <-fsm.done:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
 	}
+}
 
-	for _, state := range allowedStates {
-		if fsm.currentState == state {
-			return true
-		}
+// Output returns up to the last n lines the server printed.
+func (fsm *FSM) Output(n int) []string {
+	return fsm.output.Lines(n)
+}
+
+// Server returns the managed server.
+func (fsm *FSM) Server() *server.Server {
+	return fsm.server
+}
+
+// action hands the request over to the FSM goroutine and returns once it was
+// accepted or rejected. The transition itself happens asynchronously.
+func (fsm *FSM) action(req request) error {
+	req.result = make(chan error, 1)
+
+	select {
+	case fsm.requests <- req:
+	case <-fsm.done:
+		return ErrClosed
 	}
 
-	return false
+	return <-req.result
 }
 
-func (fsm *FSM) action(a Action, s StateT) error {
-	if !fsm.isActionAllowed(a) {
-		return ErrActionNotAllowed
+func (fsm *FSM) handle(req request) error {
+	switch req.action {
+	case ActionRender:
+		return fsm.render()
+	case ActionCheckUpdate:
+		return fsm.checkUpdate()
+	case ActionReload:
+		return fsm.reload()
 	}
 
-	fsm.ChangeState(s)
+	target, ok := fsm.actions[req.action][fsm.current]
+	if !ok {
+		return fsm.actionError(req.action)
+	}
+
+	switch req.action {
+	case ActionAdopt:
+		fsm.adoptee = req.proc
+	case ActionReset:
+		fsm.resetting = true
+	case ActionStop:
+		if fsm.current == StateTUpdating {
+			fsm.stopAfterUpdate = true
+		}
+	}
+
+	fsm.changeState(target, fmt.Sprintf("%s requested", req.action))
 	return nil
 }
 
-func (fsm *FSM) ChangeState(state StateT) {
-	if desiredState, ok := fsm.states[state]; ok {
-		fsm.desiredState = desiredState
+// changeState schedules a transition. Must be called from the FSM goroutine.
+func (fsm *FSM) changeState(state StateT, reason string) {
+	if _, ok := fsm.states[state]; ok {
+		fsm.pending = &transition{to: state, reason: reason}
 	}
 }
 
-func (fsm *FSM) Transition() {
-	if fsm.desiredState != fsm.currentState {
-		fsm.server.Settings.Log.Debug(fmt.Sprintf("Transitioning from %T to %T", fsm.currentState, fsm.desiredState))
-		if fsm.currentState != nil {
-			fsm.currentState.Exit()
+func (fsm *FSM) transition() {
+	for fsm.pending != nil {
+		next := *fsm.pending
+		fsm.pending = nil
+
+		if next.to == fsm.current {
+			continue
 		}
-		fsm.currentState = fsm.desiredState
-		fsm.currentState.Enter(fsm)
+
+		from := fsm.current
+		fsm.server.Settings.Log.Debug(fmt.Sprintf("Transitioning from %s to %s", from, next.to))
+
+		// Whatever the old state was busy with is of no use anymore
+		fsm.stopJob()
+		fsm.states[from].Exit(fsm)
+
+		fsm.current = next.to
+		fsm.mu.Lock()
+		fsm.state = next.to
+		fsm.mu.Unlock()
+
+		// Published before entering, so events emitted by Enter follow the
+		// transition that caused them
+		fsm.emit(Event{
+			Type:   EventTransition,
+			From:   from,
+			To:     next.to,
+			Reason: next.reason,
+			Err:    next.err,
+		})
+
+		fsm.states[next.to].Enter(fsm)
 	}
 }
 
 func (fsm *FSM) handleError(err error) {
+	fsm.mu.Lock()
 	fsm.err = err
+	fsm.mu.Unlock()
+
 	fsm.server.Settings.Log.Error(err.Error())
-	fsm.ChangeState(StateTStopped)
+	fsm.changeState(StateTErrored, err.Error())
+	fsm.pending.err = err
+}
+
+func (fsm *FSM) clearError() {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+
+	fsm.err = nil
+}
+
+func (fsm *FSM) setStartedAt(t time.Time) {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+
+	fsm.startedAt = t
+}
+
+func (fsm *FSM) setNextRestart(t time.Time) {
+	fsm.mu.Lock()
+	defer fsm.mu.Unlock()
+
+	fsm.nextRestart = t
+}
+
+func (fsm *FSM) restartC() <-chan time.Time {
+	if fsm.restartTimer == nil {
+		return nil
+	}
+
+	return fsm.restartTimer.C
+}
+
+func (fsm *FSM) renderC() <-chan time.Time {
+	if fsm.renderTicker == nil {
+		return nil
+	}
+
+	return fsm.renderTicker.C
+}
+
+func (fsm *FSM) render() error {
+	err := fsm.server.Render()
+	fsm.emit(Event{Type: EventRender, Err: err})
+	return err
+}
+
+func (fsm *FSM) reload() error {
+	var apply func()
+	err := fsm.server.Settings.Reload(func(next *settings.Settings) error {
+		var err error
+		apply, err = fsm.configure(&server.Server{Path: fsm.server.Path, Settings: next})
+		return err
+	})
+
+	if err != nil {
+		fsm.server.Settings.Log.Error("Failed to reload settings, keeping the old ones", "error", err.Error())
+	} else {
+		apply()
+		fsm.server.Settings.Log.Info("Reloaded settings")
+	}
+
+	fsm.emit(Event{Type: EventReload, Err: err})
+	return err
+}
+
+// configure derives the settings that are read once by the FSM from sv and
+// returns a function applying them, which cannot fail.
+func (fsm *FSM) configure(sv *server.Server) (func(), error) {
+	procOpts, err := fsm.processOptions(sv)
+	if err != nil {
+		return nil, err
+	}
+
+	restartConfig, err := restartConfig(sv)
+	if err != nil {
+		return nil, err
+	}
+
+	ready, err := readyConfig(sv)
+	if err != nil {
+		return nil, err
+	}
+
+	outputConfig, err := outputConfig(sv)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		fsm.proc.Configure(procOpts...)
+		fsm.restarts.Configure(restartConfig)
+		fsm.ready = ready
+		fsm.output.Configure(outputConfig)
+	}, nil
+}
+
+// processOptions returns the options of the process of sv, which writes
+// its output to the server log.
+func (fsm *FSM) processOptions(sv *server.Server) ([]prbf2proc.Option, error) {
+	opts, err := sv.ProcessOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, prbf2proc.WithOutput(fsm.output)), nil
+}
+
+func (fsm *FSM) checkUpdate() error {
+	// A busy state, like an Exited one about to restart, is not interrupted
+	if !slices.Contains(fsm.allowedActions(fsm.current), ActionCheckUpdate) || fsm.job != nil {
+		return fsm.actionError(ActionCheckUpdate)
+	}
+
+	var available bool
+	fsm.startJob(func(context.Context) error {
+		var err error
+		available, err = fsm.updater.IsNewVersionAvailable()
+		return err
+	}, func(err error) {
+		log := fsm.server.Settings.Log
+
+		switch {
+		case err != nil:
+			log.Error("Failed to check for updates", "error", err.Error())
+			fsm.emit(Event{Type: EventUpdateChecked, Err: err})
+			return
+		case !available:
+			log.Info("Server is up to date")
+			fsm.emit(Event{Type: EventUpdateChecked, Reason: "up to date"})
+			return
+		}
+
+		fsm.emit(Event{Type: EventUpdateChecked, Reason: "new version available"})
+
+		if fsm.current == StateTStopped {
+			fsm.stopAfterUpdate = true
+			fsm.changeState(StateTUpdating, "new version available")
+			return
+		}
+
+		fsm.changeState(StateTRestarting, "new version available")
+	})
+
+	return nil
+}
+
+// actionError must be called from the FSM goroutine.
+func (fsm *FSM) actionError(action Action) error {
+	return &ActionError{
+		Action:  action,
+		State:   fsm.current,
+		Allowed: fsm.allowedActions(fsm.current),
+	}
+}
+
+// allowedActions lists the actions accepted in state, in a stable order.
+func (fsm *FSM) allowedActions(state StateT) []Action {
+	allowed := []Action{ActionRender, ActionReload}
+
+	for action, from := range fsm.actions {
+		if _, ok := from[state]; ok {
+			allowed = append(allowed, action)
+		}
+	}
+
+	switch state {
+	case StateTRunning, StateTExited, StateTStopped:
+		allowed = append(allowed, ActionCheckUpdate)
+	}
+
+	slices.Sort(allowed)
+
+	return allowed
+}
+
+// update runs the updater and reports its progress as events. It is run as
+// a job, off the FSM goroutine.
+func (fsm *FSM) update() error {
+	fsm.emit(Event{Type: EventUpdateStarted})
+
+	result, err := fsm.updater.Update()
+
+	e := Event{Type: EventUpdateFinished, Err: err}
+	if result != nil {
+		e.Reason = fmt.Sprintf("updated from %s to %s", result.OldVersion, result.NewVersion)
+	}
+	fsm.emit(e)
+
+	return err
+}
+
+func outputConfig(sv *server.Server) (serverlog.Config, error) {
+	config := sv.Settings.Config()
+
+	// Invalid limits are left at their defaults
+	return config.Output.WithDefaults(), config.Output.Validate()
+}
+
+func restartConfig(sv *server.Server) (settings.RestartConfig, error) {
+	config := sv.Settings.Config()
+
+	err := config.Restart.Validate()
+	if err != nil {
+		return settings.DefaultRestartConfig(), err
+	}
+
+	return config.Restart.WithDefaults(), nil
 }
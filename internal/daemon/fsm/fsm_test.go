@@ -0,0 +1,410 @@
+//go:build linux
+
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/server"
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/internal/testserver"
+	"github.com/sboon-gg/svctl/pkg/prbf2update"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeServer = `#!/bin/sh
+exec sleep 1000
+`
+
+	testConfig = `loggers: []
+restart:
+  initial_delay: 1ms
+  max_delay: 5ms
+`
+)
+
+type fakeUpdater struct{}
+
+func (u *fakeUpdater) CurrentVersion() (string, error)      { return "1.0.0.0", nil }
+func (u *fakeUpdater) IsNewVersionAvailable() (bool, error) { return false, nil }
+func (u *fakeUpdater) Update() (*prbf2update.Result, error) { return nil, nil }
+
+func newTestFSM(t *testing.T) *FSM {
+	t.Helper()
+
+	return newTestFSMWith(t, fakeServer, testConfig)
+}
+
+func newTestFSMWith(t *testing.T, script, config string) *FSM {
+	t.Helper()
+
+	dir := testserver.New(t, script, map[string]string{
+		settings.SvctlDir + "/" + settings.ConfigFile: config,
+	})
+
+	sv, err := server.Open(dir, filepath.Join(dir, settings.SvctlDir))
+	require.NoError(t, err)
+
+	fsm := newFSM(sv, &fakeUpdater{})
+	t.Cleanup(func() {
+		_ = fsm.Stop()
+		fsm.Close()
+		_ = fsm.proc.Stop()
+	})
+
+	return fsm
+}
+
+func waitForState(t *testing.T, events <-chan Event, state StateT) Event {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+
+	for {
+		select {
+		case e := <-events:
+			if e.Type == EventTransition && e.To == state {
+				return e
+			}
+		case <-timeout:
+			t.Fatalf("timed out waiting for state %s", state)
+		}
+	}
+}
+
+func TestFSM_StartStop(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	status := fsm.Status()
+	assert.Equal(t, StateTRunning, status.State)
+	assert.Greater(t, status.Pid, 0)
+	assert.Equal(t, "1.0.0.0", status.Version)
+
+	pid := status.Pid
+
+	require.NoError(t, fsm.Stop())
+	waitForState(t, events, StateTStopped)
+
+	assert.Equal(t, StateTStopped, fsm.State())
+	assert.Equal(t, -1, fsm.Pid())
+	assert.Eventually(t, func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestFSM_RestartsExitedProcess(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	pid := fsm.Pid()
+	require.NoError(t, syscall.Kill(pid, syscall.SIGKILL))
+
+	e := waitForState(t, events, StateTExited)
+	assert.Equal(t, "process exited", e.Reason)
+
+	waitForState(t, events, StateTRunning)
+
+	status := fsm.Status()
+	assert.NotEqual(t, pid, status.Pid)
+	assert.Equal(t, 1, status.Restarts)
+}
+
+func crashLoop(t *testing.T, fsm *FSM, events <-chan Event) {
+	t.Helper()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	for i := 0; i < settings.DefaultRestartConfig().MaxRestarts; i++ {
+		require.NoError(t, syscall.Kill(fsm.Pid(), syscall.SIGKILL))
+		waitForState(t, events, StateTRunning)
+	}
+
+	require.NoError(t, syscall.Kill(fsm.Pid(), syscall.SIGKILL))
+	e := waitForState(t, events, StateTErrored)
+	assert.Error(t, e.Err)
+
+	status := fsm.Status()
+	assert.Equal(t, StateTErrored, status.State)
+	assert.EqualError(t, status.Err, "max restarts reached")
+	assert.Eventually(t, func() bool {
+		return fsm.Pid() == -1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.ErrorIs(t, fsm.Start(), ErrActionNotAllowed)
+}
+
+func TestFSM_ErroredStop(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	crashLoop(t, fsm, events)
+
+	require.NoError(t, fsm.Stop())
+	waitForState(t, events, StateTCleaningError)
+	waitForState(t, events, StateTStopped)
+
+	status := fsm.Status()
+	assert.NoError(t, status.Err)
+	assert.Equal(t, 0, status.Restarts)
+}
+
+func TestFSM_ErroredReset(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	crashLoop(t, fsm, events)
+
+	require.NoError(t, fsm.Reset())
+	waitForState(t, events, StateTCleaningError)
+	waitForState(t, events, StateTRunning)
+
+	status := fsm.Status()
+	assert.NoError(t, status.Err)
+	assert.Greater(t, status.Pid, 0)
+}
+
+func TestFSM_RestartBackoff(t *testing.T) {
+	fsm := newTestFSMWith(t, fakeServer, "loggers: []\nrestart:\n  initial_delay: 500ms\n")
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	require.NoError(t, syscall.Kill(fsm.Pid(), syscall.SIGKILL))
+	waitForState(t, events, StateTExited)
+
+	assert.Eventually(t, func() bool {
+		status := fsm.Status()
+		return status.State == StateTExited && status.NextRestart.After(time.Now())
+	}, time.Second, 10*time.Millisecond)
+
+	waitForState(t, events, StateTRunning)
+	assert.True(t, fsm.Status().NextRestart.IsZero())
+}
+
+func TestFSM_RestartFromExited(t *testing.T) {
+	fsm := newTestFSMWith(t, fakeServer, "loggers: []\nrestart:\n  initial_delay: 1m\n")
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	require.NoError(t, syscall.Kill(fsm.Pid(), syscall.SIGKILL))
+	waitForState(t, events, StateTExited)
+	assert.Equal(t, 0, fsm.Status().Restarts)
+
+	// Restarting before the backoff ran out is a single restart
+	require.NoError(t, fsm.Restart())
+	waitForState(t, events, StateTRunning)
+	assert.Equal(t, 1, fsm.Status().Restarts)
+}
+
+func TestFSM_RestartPolicyOnFailure(t *testing.T) {
+	fsm := newTestFSMWith(t, "#!/bin/sh\nsleep 0.2\n", "loggers: []\nrestart:\n  policy: on-failure\n")
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTExited)
+
+	e := waitForState(t, events, StateTStopped)
+	assert.Equal(t, "process exited cleanly", e.Reason)
+	assert.Equal(t, 0, fsm.Status().Restarts)
+}
+
+func TestFSM_Wait(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, fsm.Wait(ctx, StateTRunning, fsm.Start))
+	assert.Equal(t, StateTRunning, fsm.State())
+
+	require.NoError(t, fsm.Wait(ctx, StateTStopped, fsm.Stop))
+	assert.Equal(t, StateTStopped, fsm.State())
+}
+
+func TestFSM_ReadyUptime(t *testing.T) {
+	fsm := newTestFSMWith(t, "#!/bin/sh\nsleep 0.2\n", "loggers: []\nrestart:\n  policy: never\nready:\n  uptime: 2s\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := fsm.Wait(ctx, StateTRunning, fsm.Start)
+	assert.ErrorIs(t, err, ErrNotReached)
+	assert.ErrorContains(t, err, "process exited cleanly")
+}
+
+func TestFSM_ReadyTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	fsm := newTestFSMWith(t, fakeServer, fmt.Sprintf("loggers: []\nready:\n  tcp: %s\n  timeout: 2s\n", l.Addr()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, fsm.Wait(ctx, StateTRunning, fsm.Start))
+}
+
+func TestFSM_ReadyTimeout(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	fsm := newTestFSMWith(t, fakeServer, fmt.Sprintf("loggers: []\nready:\n  tcp: %s\n  timeout: 300ms\n", addr))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = fsm.Wait(ctx, StateTRunning, fsm.Start)
+	assert.ErrorIs(t, err, ErrNotReached)
+	assert.ErrorContains(t, err, "not answering")
+	assert.Equal(t, StateTErrored, fsm.State())
+	assert.Eventually(t, func() bool {
+		return fsm.Pid() == -1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestFSM_StopWhileStarting(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	fsm := newTestFSMWith(t, fakeServer, fmt.Sprintf("loggers: []\nready:\n  tcp: %s\n  timeout: 1m\n", addr))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, fsm.Wait(ctx, StateTStarting, fsm.Start))
+	pid := fsm.Pid()
+
+	require.NoError(t, fsm.Wait(ctx, StateTStopped, fsm.Stop))
+	assert.Equal(t, -1, fsm.Pid())
+	assert.Eventually(t, func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestFSM_ActionsWhileStopping(t *testing.T) {
+	fsm := newTestFSMWith(t, "#!/bin/sh\ntrap '' TERM\nwhile :; do sleep 0.1; done\n", "loggers: []\nstop:\n  timeout: 1s\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, fsm.Wait(ctx, StateTRunning, fsm.Start))
+	require.NoError(t, fsm.Wait(ctx, StateTStopping, fsm.Stop))
+
+	// The FSM goroutine is not blocked by the stopping process
+	start := time.Now()
+	require.NoError(t, fsm.Render())
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+
+	require.NoError(t, fsm.Wait(ctx, StateTStopped, fsm.Stop))
+}
+
+func TestFSM_ActionNotAllowed(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	assert.ErrorIs(t, fsm.Stop(), ErrActionNotAllowed)
+	assert.ErrorIs(t, fsm.Restart(), ErrActionNotAllowed)
+	assert.ErrorIs(t, fsm.Reset(), ErrActionNotAllowed)
+
+	var actionErr *ActionError
+	require.ErrorAs(t, fsm.Stop(), &actionErr)
+	assert.Equal(t, ActionStop, actionErr.Action)
+	assert.Equal(t, StateTStopped, actionErr.State)
+	assert.Equal(t, []Action{ActionStart, ActionAdopt, ActionRender, ActionCheckUpdate, ActionReload}, actionErr.Allowed)
+}
+
+func TestFSM_Close(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Ping(context.Background()))
+
+	fsm.Close()
+
+	assert.ErrorIs(t, fsm.Start(), ErrClosed)
+	assert.ErrorIs(t, fsm.Ping(context.Background()), ErrClosed)
+
+	_, ok := <-events
+	assert.False(t, ok, "subscriber channel not closed")
+
+	_, events, _ = fsm.Subscribe()
+	_, ok = <-events
+	assert.False(t, ok, "subscriber channel not closed")
+}
+
+func TestFSM_EventOrder(t *testing.T) {
+	fsm := newTestFSM(t)
+
+	_, events, unsubscribe := fsm.Subscribe()
+	defer unsubscribe()
+
+	require.NoError(t, fsm.Start())
+	waitForState(t, events, StateTRunning)
+
+	history, _, unsubscribe := fsm.Subscribe()
+	unsubscribe()
+
+	var types []string
+	for _, e := range history {
+		if e.Type == EventTransition {
+			types = append(types, e.To.String())
+		} else {
+			types = append(types, e.Type.String())
+		}
+	}
+
+	assert.Equal(t, []string{"Starting", "Render", "Running"}, types)
+}
+
+func TestFSM_Output(t *testing.T) {
+	fsm := newTestFSMWith(t, "#!/bin/sh\necho started\necho failed to load map >&2\nexec sleep 1000\n", testConfig)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, fsm.Wait(ctx, StateTRunning, fsm.Start))
+
+	assert.Eventually(t, func() bool {
+		return slices.Equal(fsm.Output(0), []string{"started", "failed to load map"})
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,58 @@
+package fsm
+
+import "context"
+
+// job is a long running operation of the current state, like stopping the
+// process or updating the server. It runs off the FSM goroutine so actions
+// are still handled meanwhile.
+type job struct {
+	result <-chan error
+	cancel func()
+	// done is called with the result on the FSM goroutine
+	done func(error)
+}
+
+// startJob runs run in the background and hands its result to done on the
+// FSM goroutine. The job is cancelled and its result dropped when the state
+// changes before it finishes. Must be called from the FSM goroutine.
+func (fsm *FSM) startJob(run func(ctx context.Context) error, done func(error)) {
+	fsm.stopJob()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+
+	fsm.job = &job{
+		result: result,
+		cancel: cancel,
+		done:   done,
+	}
+
+	go func() {
+		result <- run(ctx)
+	}()
+}
+
+// finishJob must be called from the FSM goroutine.
+func (fsm *FSM) finishJob(err error) {
+	j := fsm.job
+	fsm.job = nil
+
+	j.cancel()
+	j.done(err)
+}
+
+// stopJob must be called from the FSM goroutine.
+func (fsm *FSM) stopJob() {
+	if fsm.job != nil {
+		fsm.job.cancel()
+		fsm.job = nil
+	}
+}
+
+func (fsm *FSM) jobC() <-chan error {
+	if fsm.job == nil {
+		return nil
+	}
+
+	return fsm.job.result
+}
@@ -0,0 +1,95 @@
+package fsm
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/server"
+	"github.com/sboon-gg/svctl/internal/settings"
+)
+
+const readyPollInterval = 500 * time.Millisecond
+
+// probeReady waits until the readiness conditions of config hold. The
+// process exiting meanwhile is noticed by the FSM goroutine, which cancels
+// ctx.
+func probeReady(ctx context.Context, config settings.ReadyConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, config.Timeout)
+	defer cancel()
+
+	notReady := func(reason string) error {
+		return fmt.Errorf("server not ready after %s: %s", config.Timeout, reason)
+	}
+
+	if config.Uptime > 0 {
+		select {
+		case <-time.After(config.Uptime):
+		case <-ctx.Done():
+			return notReady(fmt.Sprintf("did not stay up for %s", config.Uptime))
+		}
+	}
+
+	if config.TCP == "" {
+		return nil
+	}
+
+	var dialer net.Dialer
+	ticker := time.NewTicker(readyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		conn, err := dialer.DialContext(ctx, "tcp", config.TCP)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return notReady(fmt.Sprintf("%s is not answering: %v", config.TCP, err))
+		}
+	}
+}
+
+// startProbe runs probeReady in the background, its result is received by
+// the FSM goroutine. Must be called from the FSM goroutine.
+func (fsm *FSM) startProbe() {
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+
+	fsm.cancelProbe = cancel
+	fsm.probeResult = result
+
+	config := fsm.ready
+	go func() {
+		result <- probeReady(ctx, config)
+	}()
+}
+
+// stopProbe must be called from the FSM goroutine.
+func (fsm *FSM) stopProbe() {
+	if fsm.cancelProbe != nil {
+		fsm.cancelProbe()
+		fsm.cancelProbe = nil
+	}
+
+	fsm.probeResult = nil
+}
+
+func readyConfig(sv *server.Server) (settings.ReadyConfig, error) {
+	config := sv.Settings.Config()
+
+	err := config.Ready.Validate()
+	if err != nil {
+		return settings.ReadyConfig{}, err
+	}
+
+	if !config.Ready.Enabled() {
+		return settings.ReadyConfig{}, nil
+	}
+
+	return config.Ready.WithDefaults(), nil
+}
@@ -1,54 +1,123 @@
 package fsm
 
 import (
-	"context"
+	"math/rand"
+	"sync"
 	"time"
-)
 
-const maxRestarts = 5
+	"github.com/sboon-gg/svctl/internal/settings"
+)
 
+// restarter applies the restart policy: it counts restarts within a sliding
+// window and computes the exponential backoff before the next attempt.
 type restarter struct {
-	numRestarts uint8
-	timer       *time.Timer
-	cancel      context.CancelFunc
+	mu       sync.Mutex
+	config   settings.RestartConfig
+	restarts []time.Time
+}
+
+func newRestarter(config settings.RestartConfig) *restarter {
+	return &restarter{
+		config: config,
+	}
+}
+
+// Configure replaces the restart policy, restarts counted so far are kept.
+func (r *restarter) Configure(config settings.RestartConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.config = config
 }
 
-func (r *restarter) restartTimer() {
-	ctx, cancel := context.WithCancel(context.Background())
-	r.cancel = cancel
+// ShouldRestart reports whether a process that exited with exitErr is to be
+// restarted according to the policy.
+func (r *restarter) ShouldRestart(exitErr error) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	if r.timer == nil {
-		r.timer = time.NewTimer(time.Minute)
-	} else {
-		r.cancel()
-		r.timer.Stop()
-		r.timer.Reset(time.Minute)
+	switch r.config.Policy {
+	case settings.RestartNever:
+		return false
+	case settings.RestartOnFailure:
+		return exitErr != nil
+	default:
+		return true
+	}
+}
+
+// Delay returns the backoff before the next restart, doubling with every
+// restart within the window. Half of the delay is randomized so several
+// servers crashing at once don't restart in lockstep.
+func (r *restarter) Delay() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+
+	delay := r.config.InitialDelay
+	for i := 0; i < len(r.restarts) && delay < r.config.MaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > r.config.MaxDelay {
+		delay = r.config.MaxDelay
 	}
 
-	go func() {
-		select {
-		case <-ctx.Done():
-			return
-		case <-r.timer.C:
-			r.Reset()
-		}
-	}()
+	if delay <= 0 {
+		return 0
+	}
+
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)+1))
 }
 
 func (r *restarter) Increment() {
-	r.numRestarts++
-	r.restartTimer()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+	r.restarts = append(r.restarts, time.Now())
 }
 
 func (r *restarter) Reset() {
-	if r.timer != nil {
-		r.cancel()
-		r.timer.Stop()
-	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.restarts = nil
+}
+
+func (r *restarter) Count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-	r.numRestarts = 0
+	r.prune()
+	return len(r.restarts)
 }
 
 func (r *restarter) LimitReached() bool {
-	return r.numRestarts >= maxRestarts
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prune()
+	return len(r.restarts) >= r.config.MaxRestarts
+}
+
+func (r *restarter) Window() time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.config.Window
+}
+
+// prune must be called with r.mu held.
+func (r *restarter) prune() {
+	cutoff := time.Now().Add(-r.config.Window)
+
+	i := 0
+	for i < len(r.restarts) && r.restarts[i].Before(cutoff) {
+		i++
+	}
+
+	r.restarts = r.restarts[i:]
 }
@@ -0,0 +1,61 @@
+package fsm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestarter_Delay(t *testing.T) {
+	r := newRestarter(settings.RestartConfig{
+		Policy:       settings.RestartAlways,
+		MaxRestarts:  10,
+		Window:       time.Minute,
+		InitialDelay: time.Second,
+		MaxDelay:     5 * time.Second,
+	})
+
+	expected := []time.Duration{
+		time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		5 * time.Second,
+		5 * time.Second,
+	}
+
+	for _, max := range expected {
+		delay := r.Delay()
+		assert.GreaterOrEqual(t, delay, max/2)
+		assert.LessOrEqual(t, delay, max)
+
+		r.Increment()
+	}
+
+	r.Reset()
+	assert.LessOrEqual(t, r.Delay(), time.Second)
+}
+
+func TestRestarter_ShouldRestart(t *testing.T) {
+	exitErr := errors.New("exit status 1")
+
+	tests := []struct {
+		policy   settings.RestartPolicy
+		exitErr  error
+		expected bool
+	}{
+		{settings.RestartAlways, nil, true},
+		{settings.RestartAlways, exitErr, true},
+		{settings.RestartOnFailure, nil, false},
+		{settings.RestartOnFailure, exitErr, true},
+		{settings.RestartNever, nil, false},
+		{settings.RestartNever, exitErr, false},
+	}
+
+	for _, tt := range tests {
+		r := newRestarter(settings.RestartConfig{Policy: tt.policy})
+		assert.Equal(t, tt.expected, r.ShouldRestart(tt.exitErr), "policy %s, exit error %v", tt.policy, tt.exitErr)
+	}
+}
@@ -3,6 +3,7 @@ package fsm
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 )
@@ -10,7 +11,7 @@ import (
 type stateEmpty struct{}
 
 func (s *stateEmpty) Enter(fsm *FSM) {}
-func (s *stateEmpty) Exit()          {}
+func (s *stateEmpty) Exit(fsm *FSM)  {}
 
 type StateStopped struct {
 	stateEmpty
@@ -19,89 +20,130 @@ type StateStopped struct {
 func (s *StateStopped) Enter(fsm *FSM) {
 	log := fsm.server.Settings.Log.With(slog.String("state", "stopped"))
 
-	log.Debug("Stopping server")
+	fsm.clearPID(log)
 
-	err := fsm.server.Settings.StorePID(-1)
+	log.Info("Server stopped")
+}
+
+type StateStarting struct {
+	stateEmpty
+}
+
+func (s *StateStarting) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "starting"))
+
+	log.Info("Rendering templates")
+	err := fsm.render()
 	if err != nil {
-		log.Error("Failed to store PID", "error", err.Error())
+		fsm.handleError(err)
+		return
 	}
 
-	err = fsm.proc.Stop()
+	// Launch arguments are templates, render them with the current values too
+	procOpts, err := fsm.processOptions(fsm.server)
 	if err != nil {
 		fsm.handleError(err)
+		return
 	}
+	fsm.proc.Configure(procOpts...)
 
-	log.Info("Server stopped")
+	// Every run gets its own log file
+	fsm.output.Rotate()
+
+	log.Info("Starting server")
+	err = fsm.proc.Start()
+	if err != nil {
+		fsm.handleError(err)
+		return
+	}
+
+	// Recorded right away, the server may take a while to be ready
+	fsm.storeProcess(log)
+
+	if !fsm.ready.Enabled() {
+		fsm.changeState(StateTRunning, "process started")
+		return
+	}
 
-	fsm.cancel()
+	log.Info("Waiting for server to be ready")
+	fsm.exited = fsm.proc.Exited()
+	fsm.startProbe()
 }
 
-type StateRunning struct {
-	cancel context.CancelFunc
+func (s *StateStarting) Exit(fsm *FSM) {
+	fsm.stopProbe()
+	fsm.exited = nil
 }
 
-func (s *StateRunning) Enter(fsm *FSM) {
-	ctx, cancel := context.WithCancel(context.Background())
-	s.cancel = cancel
+type StateAdopting struct {
+	stateEmpty
+}
 
-	log := fsm.server.Settings.Log.With(slog.String("state", "running"))
+func (s *StateAdopting) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "adopting"))
 
-	if !fsm.proc.IsRunning() {
-		log.Info("Rendering templates")
-		err := fsm.server.Render()
-		if err != nil {
-			fsm.handleError(err)
-			return
-		}
+	proc := fsm.adoptee
+	fsm.adoptee = nil
 
-		log.Info("Starting server")
-		err = fsm.proc.Start()
-		if err != nil {
-			fsm.handleError(err)
-			return
-		}
+	err := fsm.proc.Adopt(proc)
+	if err != nil {
+		// Process can be already dead
+		log.Info("Failed to adopt process", "error", err.Error())
+		fsm.changeState(StateTStopped, "adoption failed")
+		return
 	}
 
+	log.Info("Process adopted", slog.Int("pid", proc.Pid))
+	fsm.changeState(StateTRunning, "process adopted")
+}
+
+type StateRunning struct{}
+
+func (s *StateRunning) Enter(fsm *FSM) {
 	pid := fsm.proc.Pid()
 
-	log = log.With(slog.Int("pid", pid))
+	log := fsm.server.Settings.Log.With(slog.String("state", "running"), slog.Int("pid", pid))
 
-	err := fsm.server.Settings.StorePID(pid)
-	if err != nil {
-		log.Error("Failed to store PID", "error", err.Error())
+	fsm.storeProcess(log)
+
+	fsm.setStartedAt(time.Now())
+
+	fsm.exited = fsm.proc.Exited()
+	fsm.renderTicker = time.NewTicker(renderInterval)
+
+	log.Info("Server running")
+}
+
+func (s *StateRunning) Exit(fsm *FSM) {
+	fsm.exited = nil
+
+	if fsm.renderTicker != nil {
+		fsm.renderTicker.Stop()
+		fsm.renderTicker = nil
 	}
+}
 
-	go func() {
-		fsm.proc.Wait()
-		log.Debug("Process exited")
-
-		cancel()
-		fsm.ChangeState(StateTRestarting)
-	}()
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			default:
-				err := fsm.server.Render()
-				if err != nil {
-					log.Error(errors.Join(errors.New("Failed to render templates"), err).Error())
-				}
-				time.Sleep(1 * time.Minute)
-			}
-		}
-	}()
+type StateStopping struct {
+	stateEmpty
 }
 
-func (s *StateRunning) Exit() {
-	s.cancel()
+func (s *StateStopping) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "stopping"))
+
+	log.Debug("Stopping server")
+
+	fsm.startJob(fsm.proc.StopContext, func(err error) {
+		if err != nil {
+			fsm.handleError(err)
+			return
+		}
+
+		fsm.changeState(StateTStopped, "process stopped")
+	})
 }
 
 type StateRestarting struct {
 	stateEmpty
-	restartCtx restarter
 }
 
 func (s *StateRestarting) Enter(fsm *FSM) {
@@ -109,29 +151,81 @@ func (s *StateRestarting) Enter(fsm *FSM) {
 
 	log.Info("Restarting process")
 
-	err := fsm.server.Settings.StorePID(-1)
-	if err != nil {
-		log.Error("Failed to store PID", "error", err.Error())
+	fsm.startJob(fsm.proc.StopContext, func(err error) {
+		if err != nil {
+			fsm.handleError(err)
+			return
+		}
+
+		fsm.clearPID(log)
+
+		if fsm.restarts.LimitReached() {
+			fsm.crashLoop(log)
+			return
+		}
+
+		fsm.continueRestart()
+	})
+}
+
+type StateExited struct {
+	stateEmpty
+}
+
+func (s *StateExited) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "exited"))
+
+	exitErr := fsm.exitErr
+	fsm.exitErr = nil
+
+	if exitErr != nil {
+		log.Warn("Process exited unexpectedly", "error", exitErr.Error())
+	} else {
+		log.Warn("Process exited unexpectedly")
 	}
 
-	if s.restartCtx.LimitReached() {
-		log.Error("Max restarts reached")
-		fsm.handleError(errors.New("max restarts reached"))
-		s.restartCtx.Reset()
+	// Make sure nothing of the process group is left behind
+	_ = fsm.proc.Kill()
+
+	fsm.clearPID(log)
+
+	if !fsm.restarts.ShouldRestart(exitErr) {
+		if exitErr != nil {
+			fsm.handleError(exitErr)
+			return
+		}
+
+		fsm.changeState(StateTStopped, "process exited cleanly")
 		return
 	}
 
-	s.restartCtx.Increment()
+	if fsm.restarts.LimitReached() {
+		fsm.crashLoop(log)
+		return
+	}
 
-	_ = fsm.proc.Stop()
+	// Counted once the restart happens, a manual one meanwhile counts only
+	// once too
+	delay := fsm.restarts.Delay()
 
-	if ok, err := fsm.updater.IsNewVersionAvailable(); err == nil && ok {
-		log.Info("New version available, running update")
-		fsm.ChangeState(StateTUpdating)
+	if delay <= 0 {
+		fsm.continueRestart()
 		return
 	}
 
-	fsm.ChangeState(StateTRunning)
+	log.Info("Restarting after backoff", "delay", delay.String())
+
+	fsm.restartTimer = time.NewTimer(delay)
+	fsm.setNextRestart(time.Now().Add(delay))
+}
+
+func (s *StateExited) Exit(fsm *FSM) {
+	if fsm.restartTimer != nil {
+		fsm.restartTimer.Stop()
+		fsm.restartTimer = nil
+	}
+
+	fsm.setNextRestart(time.Time{})
 }
 
 type StateUpdating struct {
@@ -139,10 +233,124 @@ type StateUpdating struct {
 }
 
 func (s *StateUpdating) Enter(fsm *FSM) {
-	_, err := fsm.updater.Update()
+	fsm.startJob(func(context.Context) error {
+		return fsm.update()
+	}, func(err error) {
+		stop := fsm.stopAfterUpdate
+		fsm.stopAfterUpdate = false
+
+		switch {
+		case err != nil:
+			fsm.handleError(err)
+		case stop:
+			fsm.changeState(StateTStopped, "update finished")
+		default:
+			fsm.changeState(StateTStarting, "update finished")
+		}
+	})
+}
+
+// StateErrored parks the server until an operator issues Stop or Reset.
+type StateErrored struct {
+	stateEmpty
+}
+
+func (s *StateErrored) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "errored"))
+
+	_ = fsm.proc.Kill()
+
+	fsm.clearPID(log)
+
+	log.Error("Server errored, waiting for stop or reset")
+}
+
+type StateCleaningError struct {
+	stateEmpty
+}
+
+func (s *StateCleaningError) Enter(fsm *FSM) {
+	log := fsm.server.Settings.Log.With(slog.String("state", "cleaning-error"))
+
+	log.Debug("Cleaning error")
+
+	fsm.clearError()
+	fsm.restarts.Reset()
+
+	if fsm.resetting {
+		fsm.resetting = false
+		fsm.changeState(StateTStarting, "reset")
+		return
+	}
+
+	fsm.changeState(StateTStopped, "error cleaned")
+}
+
+// continueRestart accounts for a restart and runs a pending update or
+// starts the server again.
+func (fsm *FSM) continueRestart() {
+	fsm.restarts.Increment()
+
+	var available bool
+	fsm.startJob(func(context.Context) error {
+		var err error
+		available, err = fsm.updater.IsNewVersionAvailable()
+		return err
+	}, func(err error) {
+		if err == nil && available {
+			fsm.server.Settings.Log.Info("New version available, running update")
+			fsm.changeState(StateTUpdating, "new version available")
+			return
+		}
+
+		fsm.changeState(StateTStarting, "restart")
+	})
+}
+
+func (fsm *FSM) crashLoop(log *slog.Logger) {
+	log.Error("Max restarts reached")
+	fsm.emit(Event{
+		Type:   EventCrashLoop,
+		Reason: fmt.Sprintf("%d restarts within %s", fsm.restarts.Count(), fsm.restarts.Window()),
+	})
+	fsm.handleError(errors.New("max restarts reached"))
+}
+
+// storeProcess records the PID and identity of the running process for the
+// next daemon to adopt it. A server started through a script may exec into
+// another program after it was recorded, so the FSM calls this again while
+// the server is running and when it is closed with a process left behind. The identity is only written
+// when it changed.
+func (fsm *FSM) storeProcess(log *slog.Logger) {
+	pid := fsm.proc.Pid()
+
+	// Without an identity the process is not adopted after a daemon restart,
+	// which is safer than adopting a stranger
+	identity, err := fsm.proc.Identity()
 	if err != nil {
-		fsm.handleError(err)
+		log.Error("Failed to read process identity", "error", err.Error())
+	}
+
+	if pid == fsm.storedPid && identity.Equal(fsm.storedIdentity) {
+		return
 	}
 
-	fsm.ChangeState(StateTRestarting)
+	err = fsm.server.Settings.StoreProcess(pid, identity)
+	if err != nil {
+		log.Error("Failed to store PID", "error", err.Error())
+		return
+	}
+
+	fsm.storedPid, fsm.storedIdentity = pid, identity
+}
+
+func (fsm *FSM) clearPID(log *slog.Logger) {
+	err := fsm.server.Settings.StoreProcess(-1, nil)
+	if err != nil {
+		log.Error("Failed to store PID", "error", err.Error())
+	}
+
+	fsm.storedPid, fsm.storedIdentity = -1, nil
+
+	fsm.setStartedAt(time.Time{})
 }
@@ -1,22 +1,44 @@
 package fsm
 
-//go:generate go run golang.org/x/tools/cmd/stringer -type=StateT,Action -linecomment -output=types_string.go
+//go:generate go run golang.org/x/tools/cmd/stringer -type=StateT,Action,EventType -linecomment -output=types_string.go
 
 type StateT int
 
 const (
-	StateTStopped    StateT = iota // Stopped
-	StateTRunning                  // Running
-	StateTRestarting               // Restarting
-	StateTUpdating                 // Updating
-	StateTErrored                  // Errored
+	StateTStopped       StateT = iota // Stopped
+	StateTRunning                     // Running
+	StateTRestarting                  // Restarting
+	StateTUpdating                    // Updating
+	StateTErrored                     // Errored
+	StateTStarting                    // Starting
+	StateTStopping                    // Stopping
+	StateTAdopting                    // Adopting
+	StateTExited                      // Exited
+	StateTCleaningError               // CleaningError
 )
 
 type Action int
 
 const (
-	ActionStop    Action = iota // Stop
-	ActionStart                 // Start
-	ActionAdopt                 // Adopt
-	ActionRestart               // Restart
+	ActionStop        Action = iota // Stop
+	ActionStart                     // Start
+	ActionAdopt                     // Adopt
+	ActionRestart                   // Restart
+	ActionReset                     // Reset
+	ActionRender                    // Render
+	ActionCheckUpdate               // CheckUpdate
+	ActionReload                    // Reload
+)
+
+type EventType int
+
+const (
+	EventTransition     EventType = iota // Transition
+	EventRender                          // Render
+	EventUpdateStarted                   // UpdateStarted
+	EventUpdateFinished                  // UpdateFinished
+	EventCrashLoop                       // CrashLoop
+	EventScheduled                       // Scheduled
+	EventReload                          // Reload
+	EventUpdateChecked                   // UpdateChecked
 )
@@ -1,4 +1,4 @@
-// Code generated by "stringer -type=StateT,Action -linecomment -output=types_string.go"; DO NOT EDIT.
+// Code generated by "stringer -type=StateT,Action,EventType -linecomment -output=types_string.go"; DO NOT EDIT.
 
 package fsm
 
@@ -13,17 +13,23 @@ func _() {
 	_ = x[StateTRestarting-2]
 	_ = x[StateTUpdating-3]
 	_ = x[StateTErrored-4]
+	_ = x[StateTStarting-5]
+	_ = x[StateTStopping-6]
+	_ = x[StateTAdopting-7]
+	_ = x[StateTExited-8]
+	_ = x[StateTCleaningError-9]
 }
 
-const _StateT_name = "StoppedRunningRestartingUpdatingErrored"
+const _StateT_name = "StoppedRunningRestartingUpdatingErroredStartingStoppingAdoptingExitedCleaningError"
 
-var _StateT_index = [...]uint8{0, 7, 14, 24, 32, 39}
+var _StateT_index = [...]uint8{0, 7, 14, 24, 32, 39, 47, 55, 63, 69, 82}
 
 func (i StateT) String() string {
-	if i < 0 || i >= StateT(len(_StateT_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_StateT_index)-1 {
 		return "StateT(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _StateT_name[_StateT_index[i]:_StateT_index[i+1]]
+	return _StateT_name[_StateT_index[idx]:_StateT_index[idx+1]]
 }
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
@@ -33,15 +39,45 @@ func _() {
 	_ = x[ActionStart-1]
 	_ = x[ActionAdopt-2]
 	_ = x[ActionRestart-3]
+	_ = x[ActionReset-4]
+	_ = x[ActionRender-5]
+	_ = x[ActionCheckUpdate-6]
+	_ = x[ActionReload-7]
 }
 
-const _Action_name = "StopStartAdoptRestart"
+const _Action_name = "StopStartAdoptRestartResetRenderCheckUpdateReload"
 
-var _Action_index = [...]uint8{0, 4, 9, 14, 21}
+var _Action_index = [...]uint8{0, 4, 9, 14, 21, 26, 32, 43, 49}
 
 func (i Action) String() string {
-	if i < 0 || i >= Action(len(_Action_index)-1) {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_Action_index)-1 {
 		return "Action(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
-	return _Action_name[_Action_index[i]:_Action_index[i+1]]
+	return _Action_name[_Action_index[idx]:_Action_index[idx+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[EventTransition-0]
+	_ = x[EventRender-1]
+	_ = x[EventUpdateStarted-2]
+	_ = x[EventUpdateFinished-3]
+	_ = x[EventCrashLoop-4]
+	_ = x[EventScheduled-5]
+	_ = x[EventReload-6]
+	_ = x[EventUpdateChecked-7]
+}
+
+const _EventType_name = "TransitionRenderUpdateStartedUpdateFinishedCrashLoopScheduledReloadUpdateChecked"
+
+var _EventType_index = [...]uint8{0, 10, 16, 29, 43, 52, 61, 67, 80}
+
+func (i EventType) String() string {
+	idx := int(i) - 0
+	if i < 0 || idx >= len(_EventType_index)-1 {
+		return "EventType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _EventType_name[_EventType_index[idx]:_EventType_index[idx+1]]
 }
@@ -0,0 +1,100 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sboon-gg/svctl/internal/persist"
+)
+
+const (
+	lockFile = "daemon"
+	pidFile  = "daemon.pid"
+)
+
+// ErrRunning is returned when another daemon uses the same cache dir.
+var ErrRunning = errors.New("another daemon is running")
+
+// CacheDir returns the directory the daemon keeps its state in: cacheDir,
+// or svctl in the user cache directory when cacheDir is empty.
+func CacheDir(cacheDir string) (string, error) {
+	if cacheDir != "" {
+		return cacheDir, nil
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(userCacheDir, svctlDir), nil
+}
+
+// lockCacheDir makes sure only one daemon supervises the servers registered
+// in cacheDir and records its PID. The lock is released when the process
+// exits, even if it crashes.
+func lockCacheDir(cacheDir string) (*persist.FileLock, error) {
+	lock, err := tryLockCacheDir(cacheDir)
+	if err != nil {
+		return nil, err
+	}
+
+	err = persist.WriteFile(filepath.Join(cacheDir, pidFile), []byte(strconv.Itoa(os.Getpid())+"\n"), 0644)
+	if err != nil {
+		lock.Unlock()
+		return nil, err
+	}
+
+	return lock, nil
+}
+
+func tryLockCacheDir(cacheDir string) (*persist.FileLock, error) {
+	lock, err := persist.TryLock(filepath.Join(cacheDir, lockFile))
+	if errors.Is(err, persist.ErrLocked) {
+		if pid, ok := RunningPid(cacheDir); ok {
+			return nil, fmt.Errorf("%w with pid %d on %s", ErrRunning, pid, cacheDir)
+		}
+		return nil, fmt.Errorf("%w on %s", ErrRunning, cacheDir)
+	}
+
+	return lock, err
+}
+
+// RunningPid reads the PID of the daemon from the pidfile in cacheDir.
+func RunningPid(cacheDir string) (int, bool) {
+	content, err := os.ReadFile(filepath.Join(cacheDir, pidFile))
+	if err != nil {
+		return 0, false
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, false
+	}
+
+	return pid, true
+}
+
+// CheckRunning fails with ErrRunning when a daemon uses cacheDir.
+func CheckRunning(cacheDir string) error {
+	err := os.MkdirAll(cacheDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	lock, err := tryLockCacheDir(cacheDir)
+	if err != nil {
+		return err
+	}
+
+	return lock.Unlock()
+}
+
+func (d *Daemon) unlockCacheDir() {
+	os.Remove(d.cachePath(pidFile))
+	d.lock.Unlock()
+}
@@ -0,0 +1,34 @@
+package daemon
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemon_SingleInstance(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	d, err := New(cacheDir)
+	require.NoError(t, err)
+
+	pid, ok := RunningPid(cacheDir)
+	assert.True(t, ok)
+	assert.Equal(t, os.Getpid(), pid)
+
+	_, err = New(cacheDir)
+	assert.ErrorIs(t, err, ErrRunning)
+	assert.ErrorIs(t, CheckRunning(cacheDir), ErrRunning)
+
+	d.Close()
+
+	_, ok = RunningPid(cacheDir)
+	assert.False(t, ok)
+	assert.NoError(t, CheckRunning(cacheDir))
+
+	d, err = New(cacheDir)
+	require.NoError(t, err)
+	d.Close()
+}
@@ -0,0 +1,261 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/settings"
+)
+
+// reloadDelay collects the events of a single change, saving a file
+// usually takes several writes and renames.
+const reloadDelay = 500 * time.Millisecond
+
+// Reload reloads the settings of the server on path and registers its
+// schedules again, which drops skipped runs. The old settings stay in use
+// when the new ones are invalid.
+func (s *Daemon) Reload(path string) error {
+	srv, err := s.findServer(path)
+	if err != nil {
+		return err
+	}
+
+	err = srv.Reload()
+
+	// Template directories or values files may have been added
+	s.watchSettings(path, srv)
+
+	if err != nil {
+		return err
+	}
+
+	s.scheduler.RemoveGroup(path)
+	s.schedule(path, srv)
+
+	return nil
+}
+
+// ReloadAll reloads the settings of all servers.
+func (s *Daemon) ReloadAll() error {
+	var errs []error
+
+	for _, path := range s.Paths() {
+		err := s.Reload(path)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func newWatcher() *fsnotify.Watcher {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		slog.Warn("Cannot watch server settings, reload them instead", "error", err.Error())
+		return nil
+	}
+
+	return watcher
+}
+
+// settingsWatch lists the files the settings of a server are read from.
+type settingsWatch struct {
+	config    string
+	templates string
+	values    []string
+	// dirs are watched for changes of the files above
+	dirs []string
+}
+
+func newSettingsWatch(s *settings.Settings) *settingsWatch {
+	w := &settingsWatch{
+		config:    filepath.Join(s.Path(), settings.ConfigFile),
+		templates: filepath.Join(s.Path(), settings.TemplatesDir),
+		dirs:      []string{s.Path()},
+	}
+
+	// Every level of the templates, ignoring hidden ones like .git
+	_ = filepath.WalkDir(w.templates, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+
+		if path != w.templates && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		w.dirs = append(w.dirs, path)
+		return nil
+	})
+
+	for _, source := range s.Config().Values {
+		if source.File == "" {
+			continue
+		}
+
+		file := filepath.Join(s.Path(), source.File)
+		w.values = append(w.values, file)
+		w.dirs = append(w.dirs, filepath.Dir(file))
+	}
+
+	return w
+}
+
+// matches reports whether file, or a directory created at file, changes
+// the settings.
+func (w *settingsWatch) matches(file string) bool {
+	if file == w.config || slices.Contains(w.values, file) {
+		return true
+	}
+
+	if strings.HasPrefix(filepath.Base(file), ".") {
+		return false
+	}
+
+	return file == w.templates || strings.HasPrefix(file, w.templates+string(filepath.Separator))
+}
+
+// watchSettings watches the files the settings of the server on path are
+// read from. It is called again on every reload to pick up new template
+// directories and values files.
+func (s *Daemon) watchSettings(path string, srv *fsm.FSM) {
+	if s.watcher == nil {
+		return
+	}
+
+	w := newSettingsWatch(srv.Server().Settings)
+
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	old := s.watches[path]
+	s.watches[path] = w
+
+	for _, dir := range w.dirs {
+		if _, err := os.Stat(dir); err != nil {
+			continue
+		}
+
+		err := s.watcher.Add(dir)
+		if err != nil {
+			slog.Warn("Cannot watch server settings", "path", path, "dir", dir, "error", err.Error())
+		}
+	}
+
+	if old != nil {
+		s.removeWatches(old.dirs)
+	}
+}
+
+func (s *Daemon) unwatchSettings(path string) {
+	if s.watcher == nil {
+		return
+	}
+
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	old := s.watches[path]
+	delete(s.watches, path)
+
+	if old != nil {
+		s.removeWatches(old.dirs)
+	}
+}
+
+// removeWatches stops watching dirs no server needs anymore. Must be called
+// with watchMu held.
+func (s *Daemon) removeWatches(dirs []string) {
+	for _, dir := range dirs {
+		used := false
+		for _, w := range s.watches {
+			if slices.Contains(w.dirs, dir) {
+				used = true
+				break
+			}
+		}
+
+		if !used {
+			_ = s.watcher.Remove(dir)
+		}
+	}
+}
+
+// changedServers returns the paths of the servers whose settings changed
+// with file. Only the config, templates and values count, not logs or the
+// cache.
+func (s *Daemon) changedServers(file string) []string {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	var paths []string
+	for path, w := range s.watches {
+		if w.matches(file) {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// Watch reloads servers whose settings change until ctx is done.
+func (s *Daemon) Watch(ctx context.Context) {
+	if s.watcher == nil {
+		return
+	}
+
+	pending := map[string]bool{}
+
+	timer := time.NewTimer(reloadDelay)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-s.watcher.Events:
+			if !ok {
+				return
+			}
+
+			paths := s.changedServers(e.Name)
+			if len(paths) == 0 {
+				continue
+			}
+
+			for _, path := range paths {
+				pending[path] = true
+			}
+			timer.Reset(reloadDelay)
+		case err, ok := <-s.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			slog.Error("Watching server settings failed", "error", err.Error())
+		case <-timer.C:
+			for path := range pending {
+				slog.Info("Settings changed, reloading", "path", path)
+
+				err := s.Reload(path)
+				if err != nil && !errors.Is(err, ErrNotFound) {
+					slog.Error("Failed to reload settings", "path", path, "error", err.Error())
+				}
+			}
+
+			clear(pending)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeServerConfig(t *testing.T, path, config string) {
+	t.Helper()
+
+	require.NoError(t, os.WriteFile(filepath.Join(path, settings.SvctlDir, settings.ConfigFile), []byte(config), 0644))
+}
+
+func TestDaemon_Reload(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	path := newTestServer(t)
+	require.NoError(t, d.Register(path))
+
+	writeServerConfig(t, path, "loggers: []\nschedules:\n  - name: nightly\n    cron: \"0 4 * * *\"\n    action: restart\n")
+	require.NoError(t, d.Reload(path))
+
+	jobs, err := d.Schedules(path)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	assert.Equal(t, "nightly", jobs[0].Name)
+
+	// Invalid settings are rejected and the old ones kept
+	writeServerConfig(t, path, "loggers: []\nrestart:\n  policy: sometimes\n")
+	assert.ErrorIs(t, d.Reload(path), settings.ErrInvalid)
+
+	srv, err := d.findServer(path)
+	require.NoError(t, err)
+	assert.Len(t, srv.Server().Settings.Config().Schedules, 1)
+
+	// So are settings that only fail once applied
+	writeServerConfig(t, path, "loggers: []\nlaunch:\n  args:\n    +port: \"{{ .Values\"\n")
+	assert.ErrorIs(t, d.Reload(path), settings.ErrInvalid)
+	assert.Len(t, srv.Server().Settings.Config().Schedules, 1)
+}
+
+func TestDaemon_Watch(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	path := newTestServer(t)
+	require.NoError(t, d.Register(path))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go d.Watch(ctx)
+
+	writeServerConfig(t, path, "loggers: []\nschedules:\n  - name: nightly\n    cron: \"0 4 * * *\"\n    action: restart\n")
+
+	assert.Eventually(t, func() bool {
+		jobs, err := d.Schedules(path)
+		return err == nil && len(jobs) == 1
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestDaemon_WatchTemplatesAndValues(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	path := newTestServerWith(t, "#!/bin/sh\nexec sleep 1000\n", "loggers: []\nvalues:\n  - file: values/server.yaml\n")
+	svctlDir := filepath.Join(path, settings.SvctlDir)
+	require.NoError(t, os.MkdirAll(filepath.Join(svctlDir, "values"), 0755))
+	require.NoError(t, d.Register(path))
+
+	srv, err := d.findServer(path)
+	require.NoError(t, err)
+
+	_, events, unsubscribe := srv.Subscribe()
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go d.Watch(ctx)
+
+	// New directories are only watched once a reload picked them up, so
+	// changes right after may be missed
+	reloaded := func() bool {
+		timeout := time.After(2 * reloadDelay)
+		for {
+			select {
+			case e := <-events:
+				if e.Type == fsm.EventReload {
+					return true
+				}
+			case <-timeout:
+				return false
+			}
+		}
+	}
+
+	changed := func(file string) bool {
+		require.NoError(t, os.WriteFile(file, []byte("x: 1\n"), 0644))
+		return reloaded()
+	}
+
+	assert.True(t, changed(filepath.Join(svctlDir, "values", "server.yaml")), "values file")
+
+	// The templates directory is created after the server was registered
+	nested := filepath.Join(svctlDir, settings.TemplatesDir, "maps", "kashan")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+	require.True(t, reloaded(), "templates directory")
+
+	assert.Eventually(t, func() bool {
+		return changed(filepath.Join(nested, "gameplay.con"))
+	}, 5*time.Second, 10*time.Millisecond, "nested template")
+}
@@ -0,0 +1,104 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/scheduler"
+	"github.com/sboon-gg/svctl/internal/settings"
+)
+
+// schedule registers the scheduled actions configured for the server on
+// path. Invalid schedules are logged and left out.
+func (s *Daemon) schedule(path string, srv *fsm.FSM) {
+	log := srv.Server().Settings.Log
+
+	for _, sc := range srv.Server().Settings.Config().Schedules {
+		err := sc.Validate()
+		if err == nil {
+			err = s.scheduler.Add(path, sc.Name, sc.Cron, string(sc.Action), s.scheduledAction(path, srv, sc))
+		}
+		if err != nil {
+			log.Error("Invalid schedule, ignoring it", "error", err.Error())
+		}
+	}
+}
+
+func (s *Daemon) scheduledAction(path string, srv *fsm.FSM, sc settings.ScheduleConfig) scheduler.Func {
+	return func(skipped bool) error {
+		log := srv.Server().Settings.Log.With(slog.String("schedule", sc.Name), slog.String("action", string(sc.Action)))
+
+		e := fsm.Event{
+			Type:   fsm.EventScheduled,
+			Reason: fmt.Sprintf("%s: %s", sc.Name, sc.Action),
+		}
+
+		if skipped {
+			log.Info("Skipping scheduled action")
+			e.Reason += " skipped"
+			srv.Emit(e)
+			return nil
+		}
+
+		log.Info("Running scheduled action")
+
+		err := s.runAction(path, srv, sc.Action)
+		if err != nil {
+			log.Error("Scheduled action failed", "error", err.Error())
+		}
+
+		s.audit.Scheduled(sc.Name, string(sc.Action), path, err)
+
+		e.Err = err
+		srv.Emit(e)
+
+		return err
+	}
+}
+
+// runAction runs a scheduled action. Starting and stopping changes the
+// desired state just like the commands do.
+func (s *Daemon) runAction(path string, srv *fsm.FSM, action settings.ScheduleAction) error {
+	ctx := context.Background()
+
+	switch action {
+	case settings.ScheduleStart:
+		return s.Start(ctx, path, false)
+	case settings.ScheduleStop:
+		return s.Stop(ctx, path, false)
+	case settings.ScheduleRestart:
+		return s.Restart(ctx, path, false)
+	case settings.ScheduleRender:
+		return srv.Render()
+	case settings.ScheduleUpdateCheck:
+		return srv.CheckUpdate()
+	}
+
+	return fmt.Errorf("unknown action %q", action)
+}
+
+// Schedules returns the scheduled actions of the server on path, or of all
+// servers when path is empty.
+func (s *Daemon) Schedules(path string) ([]scheduler.Job, error) {
+	if path != "" {
+		_, err := s.findServer(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.scheduler.List(path), nil
+}
+
+// SkipSchedule skips the next run of a scheduled action, or undoes that
+// when skip is false.
+func (s *Daemon) SkipSchedule(path, name string, skip bool) (scheduler.Job, error) {
+	_, err := s.findServer(path)
+	if err != nil {
+		return scheduler.Job{}, err
+	}
+
+	return s.scheduler.Skip(path, name, skip)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/sboon-gg/svctl/internal/daemon/fsm"
 	"github.com/sboon-gg/svctl/internal/server"
 	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
 	"github.com/sboon-gg/svctl/pkg/prbf2update"
 )
 
@@ -21,23 +22,39 @@ func OpenServer(svPath string, updaterCache *prbf2update.Cache) (*fsm.FSM, error
 
 	cache, err := s.Settings.Cache()
 	if err != nil {
+		svFSM.Close()
 		return nil, err
 	}
 
 	if cache.PID != -1 {
-		proc, err := os.FindProcess(cache.PID)
-		if err == nil {
-			err = svFSM.Adopt(proc)
+		err = adopt(svFSM, cache)
+		if err != nil {
+			// The process is dead, or the PID was reused by another one
+			s.Settings.Log.Warn("Not adopting process", "pid", cache.PID, "error", err.Error())
+
+			err = s.Settings.StoreProcess(-1, nil)
 			if err != nil {
-				// Process can be already dead
-				cache.PID = -1
-				err = s.Settings.WriteCache(cache)
-				if err != nil {
-					return nil, err
-				}
+				svFSM.Close()
+				return nil, err
 			}
 		}
 	}
 
 	return svFSM, nil
 }
+
+// adopt hands the cached process to the FSM if it is still the one svctl
+// started.
+func adopt(svFSM *fsm.FSM, cache *settings.Cache) error {
+	err := prbf2proc.VerifyIdentity(cache.PID, cache.Process)
+	if err != nil {
+		return err
+	}
+
+	proc, err := os.FindProcess(cache.PID)
+	if err != nil {
+		return err
+	}
+
+	return svFSM.Adopt(proc)
+}
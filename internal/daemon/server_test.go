@@ -0,0 +1,121 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/server"
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenServer_Adoption(t *testing.T) {
+	path := newTestServer(t)
+
+	srv, err := OpenServer(path, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	require.NoError(t, srv.Wait(ctx, fsm.StateTRunning, srv.Start))
+
+	pid := srv.Pid()
+	t.Cleanup(func() { _ = syscall.Kill(-pid, syscall.SIGKILL) })
+
+	// The script execs sleep, likely after its identity was first recorded
+	assert.Eventually(t, func() bool {
+		identity, err := prbf2proc.ReadIdentity(pid)
+		return err == nil && filepath.Base(identity.Exe) == "sleep"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Leave the process running like a daemon restart would, which records
+	// the identity again
+	srv.Close()
+
+	sv, err := server.Open(path, filepath.Join(path, settings.SvctlDir))
+	require.NoError(t, err)
+
+	cache, err := sv.Settings.Cache()
+	require.NoError(t, err)
+	require.Equal(t, pid, cache.PID)
+	require.NotNil(t, cache.Process)
+
+	t.Run("matching identity", func(t *testing.T) {
+		srv, err := OpenServer(path, nil)
+		require.NoError(t, err)
+		defer srv.Close()
+
+		assert.Eventually(t, func() bool {
+			return srv.State() == fsm.StateTRunning
+		}, 5*time.Second, 50*time.Millisecond)
+		assert.Equal(t, pid, srv.Pid())
+	})
+
+	t.Run("reused pid", func(t *testing.T) {
+		cache.Process.StartTime--
+		require.NoError(t, sv.Settings.WriteCache(cache))
+
+		srv, err := OpenServer(path, nil)
+		require.NoError(t, err)
+		defer srv.Close()
+
+		assert.Equal(t, fsm.StateTStopped, srv.State())
+
+		cache, err := sv.Settings.Cache()
+		require.NoError(t, err)
+		assert.Equal(t, -1, cache.PID)
+		assert.Nil(t, cache.Process)
+
+		// The stranger is left alone
+		assert.NoError(t, syscall.Kill(pid, 0))
+	})
+}
+
+func TestOpenServer_AdoptionWhileStarting(t *testing.T) {
+	// Nothing listens there, so the server stays Starting
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	l.Close()
+
+	path := newTestServerWith(t, "#!/bin/sh\nexec sleep 1000\n", fmt.Sprintf("loggers: []\nready:\n  tcp: %s\n  timeout: 1m\n", addr))
+
+	srv, err := OpenServer(path, nil)
+	require.NoError(t, err)
+
+	require.NoError(t, srv.Start())
+	require.Eventually(t, func() bool {
+		return srv.State() == fsm.StateTStarting && srv.Pid() != -1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	pid := srv.Pid()
+	t.Cleanup(func() { _ = syscall.Kill(-pid, syscall.SIGKILL) })
+
+	assert.Eventually(t, func() bool {
+		identity, err := prbf2proc.ReadIdentity(pid)
+		return err == nil && filepath.Base(identity.Exe) == "sleep"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The daemon goes away before the server is ready
+	srv.Close()
+
+	srv, err = OpenServer(path, nil)
+	require.NoError(t, err)
+	defer srv.Close()
+
+	assert.Eventually(t, func() bool {
+		return srv.State() == fsm.StateTRunning
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, pid, srv.Pid())
+}
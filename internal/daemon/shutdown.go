@@ -0,0 +1,72 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+)
+
+// Shutdown applies the shutdown policy to all servers. With ShutdownStop
+// they are stopped concurrently. Those not stopped within timeout have got
+// the stop signal but are left running once the daemon is closed, they are
+// not killed. The desired state is kept, so servers are started on next
+// boot.
+func (s *Daemon) Shutdown(config ShutdownConfig) {
+	s.mu.RLock()
+	servers := make(map[string]*fsm.FSM, len(s.Servers))
+	for path, srv := range s.Servers {
+		servers[path] = srv
+	}
+	s.mu.RUnlock()
+
+	// Scheduled actions must not interfere from here on
+	s.scheduler.Close()
+
+	if config.Policy != ShutdownStop {
+		for path, srv := range servers {
+			if srv.State() == fsm.StateTStopped {
+				continue
+			}
+
+			slog.Info("Leaving server running", "path", path, "state", srv.State().String(), "pid", srv.Pid())
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for path, srv := range servers {
+		wg.Add(1)
+		go func(path string, srv *fsm.FSM) {
+			defer wg.Done()
+
+			slog.Info("Stopping server", "path", path)
+
+			err := stopServer(ctx, srv)
+			if err != nil {
+				slog.Error("Failed to stop server, leaving it running", "path", path, "error", err.Error())
+				return
+			}
+
+			slog.Info("Server stopped", "path", path)
+		}(path, srv)
+	}
+
+	wg.Wait()
+}
+
+// stopServer stops srv from whatever state it is in and waits until it is
+// Stopped.
+func stopServer(ctx context.Context, srv *fsm.FSM) error {
+	err := srv.Wait(ctx, fsm.StateTStopped, srv.Stop)
+	if errors.Is(err, fsm.ErrActionNotAllowed) && srv.State() == fsm.StateTStopped {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,66 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDaemon_ShutdownStop(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := newTestServer(t)
+	require.NoError(t, d.Register(path))
+	require.NoError(t, d.Start(ctx, path, true))
+
+	d.Shutdown(ShutdownConfig{Policy: ShutdownStop, Timeout: 5 * time.Second})
+
+	status, err := d.Status(path)
+	require.NoError(t, err)
+	assert.Equal(t, fsm.StateTStopped, status.State)
+
+	// Started again on next boot
+	desired, err := d.Desired(path)
+	require.NoError(t, err)
+	assert.True(t, desired.Running)
+}
+
+func TestDaemon_ShutdownTimeout(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	path := newTestServerWith(t, "#!/bin/sh\ntrap '' TERM\nwhile :; do sleep 0.1; done\n", "loggers: []\nstop:\n  timeout: 1m\nready:\n  uptime: 300ms\n")
+	require.NoError(t, d.Register(path))
+	// Ready only once the script ignores SIGTERM
+	require.NoError(t, d.Start(ctx, path, true))
+
+	pid := d.Servers[path].Pid()
+	t.Cleanup(func() {
+		_ = syscall.Kill(-pid, syscall.SIGKILL)
+	})
+
+	start := time.Now()
+	d.Shutdown(ShutdownConfig{Policy: ShutdownStop, Timeout: 200 * time.Millisecond})
+	d.Close()
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.NoError(t, syscall.Kill(pid, 0), "server should be left running")
+}
@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+const (
+	socketFile = "svctl.sock"
+
+	unixSocketsSupported = true
+)
+
+// DefaultListen returns the Unix socket the daemon listens on unless
+// configured otherwise: svctl.sock in $XDG_RUNTIME_DIR, SystemSocket for
+// root, or a per-user socket in the temp directory.
+func DefaultListen() string {
+	return "unix://" + filepath.Join(defaultSocketDir(), socketFile)
+}
+
+func defaultSocketDir() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	switch {
+	case dir != "":
+		return filepath.Join(dir, svctlDir)
+	case os.Getuid() == 0:
+		return filepath.Dir(SystemSocket)
+	}
+
+	return filepath.Join(os.TempDir(), fmt.Sprintf("svctl-%d", os.Getuid()))
+}
+
+// socketDir creates the directory of a socket. The default one is private
+// to the daemon user, it may be in a directory anyone can write to. Only
+// root can write to the parent of SystemSocket.
+func socketDir(dir string) error {
+	if dir != defaultSocketDir() || dir == filepath.Dir(SystemSocket) {
+		return os.MkdirAll(dir, 0755)
+	}
+
+	err := os.Mkdir(dir, 0700)
+	if err != nil && !os.IsExist(err) {
+		return err
+	}
+
+	fi, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+
+	stat, ok := fi.Sys().(*syscall.Stat_t)
+	if !fi.IsDir() || !ok || int(stat.Uid) != os.Getuid() || fi.Mode().Perm() != 0700 {
+		return fmt.Errorf("socket directory %s must be a directory owned by uid %d with mode 0700", dir, os.Getuid())
+	}
+
+	return nil
+}
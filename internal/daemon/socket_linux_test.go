@@ -0,0 +1,44 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSocketDir_Private(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", t.TempDir())
+
+	endpoint, err := ParseEndpoint(DefaultListen())
+	require.NoError(t, err)
+
+	lis, err := endpoint.Listen(SocketConfig{})
+	require.NoError(t, err)
+	lis.Close()
+
+	dir := filepath.Dir(endpoint.Address)
+	fi, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), fi.Mode().Perm())
+
+	// Created by someone else
+	require.NoError(t, os.Chmod(dir, 0777))
+	_, err = endpoint.Listen(SocketConfig{})
+	assert.ErrorContains(t, err, "mode 0700")
+}
+
+func TestDefaultListen(t *testing.T) {
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, "unix:///run/user/1000/svctl/svctl.sock", DefaultListen())
+
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	if os.Getuid() == 0 {
+		assert.Equal(t, "unix://"+SystemSocket, DefaultListen())
+	} else {
+		assert.Equal(t, "unix://"+filepath.Join(os.TempDir(), fmt.Sprintf("svctl-%d", os.Getuid()), socketFile), DefaultListen())
+	}
+}
@@ -0,0 +1,18 @@
+//go:build !linux
+
+package daemon
+
+import "os"
+
+const unixSocketsSupported = false
+
+// DefaultListen returns the endpoint the daemon listens on unless configured
+// otherwise. Callers on Unix sockets are only identified on Linux, elsewhere
+// the daemon listens on the local TCP port.
+func DefaultListen() string {
+	return "tcp://localhost:50051"
+}
+
+func socketDir(dir string) error {
+	return os.MkdirAll(dir, 0755)
+}
@@ -1,49 +1,162 @@
 package daemon
 
 import (
+	"errors"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
-	"github.com/goccy/go-yaml"
+	"github.com/sboon-gg/svctl/internal/persist"
 )
 
+// stateMigrations upgrade state files written by older versions, see
+// persist.ReadYAML.
+var stateMigrations = []persist.Migration{
+	// 1 adds the version itself
+	func(doc map[string]any) error { return nil },
+}
+
 type State struct {
-	Servers []string
+	Version int      `yaml:"version"`
+	Servers []string `yaml:"servers"`
+	// Desired holds what operators asked for each server, by path
+	Desired map[string]Desired `yaml:"desired,omitempty"`
+}
+
+// Desired is the state a server is reconciled to when the daemon boots.
+type Desired struct {
+	// Running is set by start, restart and reset and cleared by stop
+	Running bool `yaml:"running,omitempty"`
+	// Autostart starts the server on boot even if it was stopped
+	Autostart bool `yaml:"autostart,omitempty"`
+}
+
+// ShouldRun reports whether the server is started on boot.
+func (d Desired) ShouldRun() bool {
+	return d.Running || d.Autostart
 }
 
 func NewState() *State {
 	return &State{
+		Version: len(stateMigrations),
 		Servers: make([]string, 0),
+		Desired: make(map[string]Desired),
 	}
 }
 
-func (d *Daemon) State() (*State, error) {
-	var cache State
+// readState reads the saved state. A missing state file is an empty state,
+// an empty or unreadable one is an error so no server is forgotten by
+// accident.
+func readState(path string) (*State, error) {
+	var state State
 
-	if _, err := os.Stat(d.cachePath(stateFile)); os.IsNotExist(err) {
+	err := persist.ReadYAML(path, &state, stateMigrations)
+	if errors.Is(err, os.ErrNotExist) {
 		return NewState(), nil
 	}
-
-	content, err := os.ReadFile(d.cachePath(stateFile))
 	if err != nil {
 		return nil, err
 	}
 
-	err = yaml.Unmarshal(content, &cache)
-	if err != nil {
-		return nil, err
+	if state.Desired == nil {
+		state.Desired = make(map[string]Desired)
 	}
 
-	return &cache, nil
+	return &state, nil
 }
 
-func (d *Daemon) SaveState(state *State) error {
-	content, err := yaml.Marshal(state)
+// State returns a copy of the state, which is read once when the daemon is
+// created and kept in memory from then on.
+func (d *Daemon) State() *State {
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+
+	return d.state.clone()
+}
+
+func (s *State) clone() *State {
+	c := &State{
+		Version: s.Version,
+		Servers: slices.Clone(s.Servers),
+		Desired: maps.Clone(s.Desired),
+	}
+
+	if c.Desired == nil {
+		c.Desired = make(map[string]Desired)
+	}
+
+	return c
+}
+
+// updateState applies fn to the state and writes it through to the state
+// file, holding the state lock so other processes don't read it half
+// written. The state is only changed once it was saved.
+func (d *Daemon) updateState(fn func(*State)) error {
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+
+	lock, err := persist.Lock(d.cachePath(stateFile))
 	if err != nil {
 		return err
 	}
+	defer lock.Unlock()
+
+	state := d.state.clone()
+	fn(state)
+
+	err = persist.WriteYAML(d.cachePath(stateFile), state, 0644)
+	if err != nil {
+		return err
+	}
+
+	d.state = state
+
+	return nil
+}
+
+// Desired returns the desired state of the server on path.
+func (d *Daemon) Desired(path string) (Desired, error) {
+	_, err := d.findServer(path)
+	if err != nil {
+		return Desired{}, err
+	}
+
+	d.stateMu.Lock()
+	defer d.stateMu.Unlock()
+
+	return d.state.Desired[path], nil
+}
+
+// setRunning records whether the server on path should be running. Failing
+// to do so does not undo the action, it is logged instead.
+func (d *Daemon) setRunning(path string, running bool) {
+	err := d.updateState(func(state *State) {
+		desired := state.Desired[path]
+		desired.Running = running
+		state.Desired[path] = desired
+	})
+	if err != nil {
+		slog.Error("Failed to save desired state", "path", path, "error", err.Error())
+	}
+}
+
+// SetAutostart sets whether the server on path is started on boot.
+func (d *Daemon) SetAutostart(path string, autostart bool) (Desired, error) {
+	_, err := d.findServer(path)
+	if err != nil {
+		return Desired{}, err
+	}
+
+	var desired Desired
+	err = d.updateState(func(state *State) {
+		desired = state.Desired[path]
+		desired.Autostart = autostart
+		state.Desired[path] = desired
+	})
 
-	return os.WriteFile(d.cachePath(stateFile), content, 0644)
+	return desired, err
 }
 
 func (d *Daemon) cachePath(path string) string {
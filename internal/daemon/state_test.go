@@ -0,0 +1,121 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/daemon/fsm"
+	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/internal/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) string {
+	t.Helper()
+
+	return newTestServerWith(t, "#!/bin/sh\nexec sleep 1000\n", "loggers: []\n")
+}
+
+func newTestServerWith(t *testing.T, script, config string) string {
+	t.Helper()
+
+	return testserver.New(t, script, map[string]string{
+		settings.SvctlDir + "/" + settings.ConfigFile: config,
+	})
+}
+
+func stopAll(t *testing.T, d *Daemon) {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, path := range d.Paths() {
+		srv, err := d.findServer(path)
+		require.NoError(t, err)
+
+		if srv.State() != fsm.StateTStopped {
+			require.NoError(t, srv.Wait(ctx, fsm.StateTStopped, srv.Stop))
+		}
+		srv.Close()
+	}
+}
+
+func TestDaemon_Reconcile(t *testing.T) {
+	cacheDir := t.TempDir()
+	running, stopped, autostart := newTestServer(t), newTestServer(t), newTestServer(t)
+
+	d, err := New(cacheDir)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for _, path := range []string{running, stopped, autostart} {
+		require.NoError(t, d.Register(path))
+	}
+
+	require.NoError(t, d.Start(ctx, running, true))
+	require.NoError(t, d.Start(ctx, stopped, true))
+	require.NoError(t, d.Stop(ctx, stopped, true))
+
+	desired, err := d.SetAutostart(autostart, true)
+	require.NoError(t, err)
+	assert.Equal(t, Desired{Autostart: true}, desired)
+
+	// Simulate a reboot, nothing is left running to adopt
+	stopAll(t, d)
+	d.Close()
+
+	d, err = Recover(cacheDir)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		stopAll(t, d)
+		d.Close()
+	})
+
+	desired, err = d.Desired(running)
+	require.NoError(t, err)
+	assert.True(t, desired.Running)
+
+	d.Reconcile(ctx, 10*time.Millisecond)
+
+	state := func(path string) fsm.StateT {
+		status, err := d.Status(path)
+		require.NoError(t, err)
+		return status.State
+	}
+
+	assert.Eventually(t, func() bool {
+		return state(running) == fsm.StateTRunning && state(autostart) == fsm.StateTRunning
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Equal(t, fsm.StateTStopped, state(stopped))
+}
+
+func TestDaemon_DesiredInMemory(t *testing.T) {
+	d, err := New(t.TempDir())
+	require.NoError(t, err)
+	t.Cleanup(d.Close)
+
+	path := newTestServer(t)
+	require.NoError(t, d.Register(path))
+
+	_, err = d.SetAutostart(path, true)
+	require.NoError(t, err)
+
+	saved, err := readState(d.cachePath(stateFile))
+	require.NoError(t, err)
+	assert.True(t, saved.Desired[path].Autostart)
+
+	// Status queries don't read the state file
+	require.NoError(t, os.Remove(d.cachePath(stateFile)))
+
+	desired, err := d.Desired(path)
+	require.NoError(t, err)
+	assert.True(t, desired.Autostart)
+}
@@ -0,0 +1,182 @@
+// Package persist writes svctl state files so that a crash leaves either the
+// old or the new content behind, never a torn or empty file.
+package persist
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+)
+
+const versionKey = "version"
+
+var (
+	// ErrEmpty is returned for an empty state file, which svctl never writes.
+	ErrEmpty = errors.New("file is empty")
+	// ErrNewerVersion is returned for files written by a newer svctl.
+	ErrNewerVersion = errors.New("file was written by a newer version of svctl")
+	// ErrLocked is returned by TryLock when someone else holds the lock.
+	ErrLocked = errors.New("file is locked")
+)
+
+// WriteFile writes data to a temporary file next to path, syncs it and
+// renames it over path.
+func WriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	// Removing fails harmlessly once the file was renamed
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if err == nil {
+		err = tmp.Chmod(perm)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return err
+	}
+
+	return syncDir(dir)
+}
+
+// Migration upgrades a document from the version it is at in the list to
+// the next one.
+type Migration func(doc map[string]any) error
+
+// ReadYAML reads the document at path into v after migrating it to the
+// latest version, len(migrations). Documents without a version field are at
+// version 0. A missing file is reported as os.ErrNotExist.
+func ReadYAML(path string, v any, migrations []Migration) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(content)) == 0 {
+		return fmt.Errorf("%s: %w", path, ErrEmpty)
+	}
+
+	doc := map[string]any{}
+
+	err = yaml.Unmarshal(content, &doc)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	version, err := docVersion(doc)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if version > len(migrations) {
+		return fmt.Errorf("%s: %w: version %d, supported up to %d", path, ErrNewerVersion, version, len(migrations))
+	}
+
+	if version < len(migrations) {
+		for i, migrate := range migrations[version:] {
+			err := migrate(doc)
+			if err != nil {
+				return fmt.Errorf("%s: migrating to version %d: %w", path, version+i+1, err)
+			}
+		}
+
+		doc[versionKey] = len(migrations)
+
+		content, err = yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+	}
+
+	return yaml.Unmarshal(content, v)
+}
+
+func docVersion(doc map[string]any) (int, error) {
+	switch version := doc[versionKey].(type) {
+	case nil:
+		return 0, nil
+	case uint64:
+		return int(version), nil
+	case int64:
+		if version >= 0 {
+			return int(version), nil
+		}
+	case int:
+		if version >= 0 {
+			return version, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid version %v", doc[versionKey])
+}
+
+// WriteYAML marshals v and writes it atomically. v is expected to carry the
+// latest version.
+func WriteYAML(path string, v any, perm os.FileMode) error {
+	content, err := yaml.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return WriteFile(path, content, perm)
+}
+
+// FileLock is an advisory lock held on a file next to the locked one, since
+// the locked file itself is replaced on every write.
+type FileLock struct {
+	file *os.File
+}
+
+// Lock blocks until it holds the exclusive lock for path.
+func Lock(path string) (*FileLock, error) {
+	return lock(path, true)
+}
+
+// TryLock takes the exclusive lock for path, or fails with ErrLocked right
+// away when it is held.
+func TryLock(path string) (*FileLock, error) {
+	return lock(path, false)
+}
+
+func lock(path string, wait bool) (*FileLock, error) {
+	file, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	err = lockFile(file, wait)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("locking %s: %w", path, err)
+	}
+
+	return &FileLock{file: file}, nil
+}
+
+func (l *FileLock) Unlock() error {
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
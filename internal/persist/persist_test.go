@@ -0,0 +1,133 @@
+package persist
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type doc struct {
+	Version int      `yaml:"version"`
+	Names   []string `yaml:"names"`
+}
+
+var migrations = []Migration{
+	// 1 renames servers to names
+	func(doc map[string]any) error {
+		doc["names"] = doc["servers"]
+		delete(doc, "servers")
+		return nil
+	},
+}
+
+func TestWriteFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+
+	require.NoError(t, WriteFile(path, []byte("old"), 0644))
+	require.NoError(t, WriteFile(path, []byte("new"), 0600))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(content))
+
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestReadYAML(t *testing.T) {
+	dir := t.TempDir()
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("migrates", func(t *testing.T) {
+		var d doc
+		require.NoError(t, ReadYAML(write("legacy.yaml", "servers:\n- /srv\n"), &d, migrations))
+		assert.Equal(t, doc{Version: 1, Names: []string{"/srv"}}, d)
+	})
+
+	t.Run("current", func(t *testing.T) {
+		var d doc
+		require.NoError(t, ReadYAML(write("current.yaml", "version: 1\nnames:\n- /srv\n"), &d, migrations))
+		assert.Equal(t, doc{Version: 1, Names: []string{"/srv"}}, d)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var d doc
+		assert.ErrorIs(t, ReadYAML(write("empty.yaml", "\n"), &d, migrations), ErrEmpty)
+	})
+
+	t.Run("newer", func(t *testing.T) {
+		var d doc
+		assert.ErrorIs(t, ReadYAML(write("newer.yaml", "version: 2\n"), &d, migrations), ErrNewerVersion)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var d doc
+		assert.ErrorIs(t, ReadYAML(filepath.Join(dir, "missing.yaml"), &d, migrations), os.ErrNotExist)
+	})
+}
+
+func TestLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.yaml")
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		holders int
+	)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			lock, err := Lock(path)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			mu.Lock()
+			holders++
+			assert.Equal(t, 1, holders)
+			mu.Unlock()
+
+			mu.Lock()
+			holders--
+			mu.Unlock()
+
+			assert.NoError(t, lock.Unlock())
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestTryLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "daemon")
+
+	lock, err := TryLock(path)
+	require.NoError(t, err)
+
+	_, err = TryLock(path)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	require.NoError(t, lock.Unlock())
+
+	lock, err = TryLock(path)
+	require.NoError(t, err)
+	assert.NoError(t, lock.Unlock())
+}
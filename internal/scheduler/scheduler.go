@@ -0,0 +1,233 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+var ErrNotFound = errors.New("schedule not found")
+
+// Func runs a job. skipped is set when the run was skipped on request, so
+// the job can still record that it was due.
+type Func func(skipped bool) error
+
+// Job describes a scheduled job. Jobs are identified by Group and Name,
+// Action is informative only.
+type Job struct {
+	Group  string
+	Name   string
+	Spec   string
+	Action string
+
+	Next    time.Time
+	Skip    bool
+	LastRun time.Time
+	LastErr error
+}
+
+type entry struct {
+	job      Job
+	schedule cron.Schedule
+	run      Func
+}
+
+type key struct {
+	group, name string
+}
+
+// Scheduler runs jobs on cron schedules from a single goroutine. Jobs are
+// started on their own goroutines so a slow job doesn't delay the others.
+type Scheduler struct {
+	mu      sync.Mutex
+	entries map[key]*entry
+
+	wake      chan struct{}
+	quit      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func New() *Scheduler {
+	s := &Scheduler{
+		entries: make(map[key]*entry),
+		wake:    make(chan struct{}, 1),
+		quit:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go s.loop()
+
+	return s
+}
+
+// Add schedules run according to the cron expression spec, replacing a job
+// with the same group and name.
+func (s *Scheduler) Add(group, name, spec, action string, run Func) error {
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return fmt.Errorf("invalid cron expression %q: %w", spec, err)
+	}
+
+	s.mu.Lock()
+	s.entries[key{group, name}] = &entry{
+		job: Job{
+			Group:  group,
+			Name:   name,
+			Spec:   spec,
+			Action: action,
+			Next:   schedule.Next(time.Now()),
+		},
+		schedule: schedule,
+		run:      run,
+	}
+	s.mu.Unlock()
+
+	s.notify()
+
+	return nil
+}
+
+// RemoveGroup removes all jobs of group.
+func (s *Scheduler) RemoveGroup(group string) {
+	s.mu.Lock()
+	for k := range s.entries {
+		if k.group == group {
+			delete(s.entries, k)
+		}
+	}
+	s.mu.Unlock()
+
+	s.notify()
+}
+
+// Skip marks the next run of a job to be skipped, or clears the mark.
+func (s *Scheduler) Skip(group, name string, skip bool) (Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key{group, name}]
+	if !ok {
+		return Job{}, fmt.Errorf("%w: %q", ErrNotFound, name)
+	}
+
+	e.job.Skip = skip
+
+	return e.job, nil
+}
+
+// List returns the jobs of group, or all jobs when group is empty, ordered
+// by group and name.
+func (s *Scheduler) List(group string) []Job {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]Job, 0, len(s.entries))
+	for k, e := range s.entries {
+		if group == "" || k.group == group {
+			jobs = append(jobs, e.job)
+		}
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].Group != jobs[j].Group {
+			return jobs[i].Group < jobs[j].Group
+		}
+		return jobs[i].Name < jobs[j].Name
+	})
+
+	return jobs
+}
+
+// Close stops the scheduler. Jobs that are already running are not waited for.
+func (s *Scheduler) Close() {
+	s.closeOnce.Do(func() {
+		close(s.quit)
+	})
+	<-s.done
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) loop() {
+	defer close(s.done)
+
+	for {
+		var (
+			timer  *time.Timer
+			timerC <-chan time.Time
+		)
+
+		if next := s.next(); !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			timerC = timer.C
+		}
+
+		select {
+		case <-s.quit:
+			stopTimer(timer)
+			return
+		case <-s.wake:
+			stopTimer(timer)
+		case now := <-timerC:
+			s.runDue(now)
+		}
+	}
+}
+
+func (s *Scheduler) next() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next time.Time
+	for _, e := range s.entries {
+		if next.IsZero() || e.job.Next.Before(next) {
+			next = e.job.Next
+		}
+	}
+
+	return next
+}
+
+func (s *Scheduler) runDue(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.job.Next.After(now) {
+			continue
+		}
+
+		skipped := e.job.Skip
+		e.job.Skip = false
+		e.job.Next = e.schedule.Next(now)
+
+		go s.run(e, skipped)
+	}
+}
+
+func (s *Scheduler) run(e *entry, skipped bool) {
+	started := time.Now()
+	err := e.run(skipped)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e.job.LastRun = started
+	e.job.LastErr = err
+}
+
+func stopTimer(timer *time.Timer) {
+	if timer != nil {
+		timer.Stop()
+	}
+}
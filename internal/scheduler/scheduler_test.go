@@ -0,0 +1,65 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler_Add(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	require.Error(t, s.Add("a", "bad", "not a cron", "restart", nil))
+
+	require.NoError(t, s.Add("b", "nightly", "0 4 * * *", "restart", nil))
+	require.NoError(t, s.Add("a", "hourly", "@hourly", "render", nil))
+
+	jobs := s.List("")
+	require.Len(t, jobs, 2)
+	assert.Equal(t, "hourly", jobs[0].Name)
+	assert.Equal(t, "nightly", jobs[1].Name)
+	assert.True(t, jobs[1].Next.After(time.Now()))
+	assert.Equal(t, 4, jobs[1].Next.Hour())
+
+	assert.Len(t, s.List("b"), 1)
+
+	s.RemoveGroup("b")
+	assert.Empty(t, s.List("b"))
+}
+
+func TestScheduler_RunAndSkip(t *testing.T) {
+	s := New()
+	defer s.Close()
+
+	runs := make(chan bool, 2)
+	require.NoError(t, s.Add("a", "hourly", "@hourly", "render", func(skipped bool) error {
+		runs <- skipped
+		return nil
+	}))
+
+	_, err := s.Skip("a", "missing", true)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	job, err := s.Skip("a", "hourly", true)
+	require.NoError(t, err)
+	assert.True(t, job.Skip)
+
+	due := s.List("a")[0].Next
+
+	s.runDue(due)
+	assert.True(t, <-runs)
+
+	job = s.List("a")[0]
+	assert.False(t, job.Skip)
+	assert.True(t, job.Next.After(due))
+
+	s.runDue(job.Next)
+	assert.False(t, <-runs)
+
+	assert.Eventually(t, func() bool {
+		return !s.List("a")[0].LastRun.IsZero()
+	}, time.Second, 10*time.Millisecond)
+}
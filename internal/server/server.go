@@ -2,6 +2,7 @@ package server
 
 import (
 	"github.com/sboon-gg/svctl/internal/settings"
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
 	"github.com/sboon-gg/svctl/pkg/templates"
 )
 
@@ -23,7 +24,8 @@ func Open(serverPath, settingsPath string) (*Server, error) {
 }
 
 func (s *Server) Render() error {
-	if s.Settings.Templates == nil {
+	renderer := s.Settings.Templates()
+	if renderer == nil {
 		return nil
 	}
 
@@ -32,11 +34,12 @@ func (s *Server) Render() error {
 		return err
 	}
 
-	return s.Settings.Templates.RenderInto(s.Path, values)
+	return renderer.RenderInto(s.Path, values)
 }
 
 func (s *Server) DryRender() ([]templates.RenderOutput, error) {
-	if s.Settings.Templates == nil {
+	renderer := s.Settings.Templates()
+	if renderer == nil {
 		return nil, nil
 	}
 
@@ -45,5 +48,35 @@ func (s *Server) DryRender() ([]templates.RenderOutput, error) {
 		return nil, err
 	}
 
-	return s.Settings.Templates.Render(values)
+	return renderer.Render(values)
+}
+
+// ProcessOptions returns options for the server process derived from the
+// config. Launch arguments are rendered with the current values.
+func (s *Server) ProcessOptions() ([]prbf2proc.Option, error) {
+	config := s.Settings.Config()
+
+	var opts []prbf2proc.Option
+
+	if config.Stop != nil {
+		if config.Stop.Signal != "" {
+			sig, err := prbf2proc.ParseSignal(config.Stop.Signal)
+			if err != nil {
+				return nil, err
+			}
+			opts = append(opts, prbf2proc.WithStopSignal(sig))
+		}
+
+		if config.Stop.Timeout > 0 {
+			opts = append(opts, prbf2proc.WithStopTimeout(config.Stop.Timeout))
+		}
+	}
+
+	launch, err := s.Settings.Launch()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, prbf2proc.WithLaunch(launch))
+
+	return opts, nil
 }
@@ -0,0 +1,244 @@
+package serverlog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	filePrefix = "server-"
+	fileSuffix = ".log"
+	timeFormat = "20060102T150405.000000000"
+)
+
+// Config limits the log files and the lines kept in memory.
+type Config struct {
+	// MaxSize is the size in bytes a new file is started at
+	MaxSize int64
+	// MaxFiles is how many files are kept, the current one included
+	MaxFiles int
+	// Lines is how many of the last lines are kept in memory
+	Lines int
+}
+
+// Log collects what a server prints. The daemon reads the output from a
+// pipe and writes it here, into a server-<timestamp>.log file per run that
+// is replaced by a new one once it reaches MaxSize. The last lines are kept
+// in memory too.
+type Log struct {
+	dir     string
+	onError func(error)
+
+	mu      sync.Mutex
+	config  Config
+	file    *os.File
+	size    int64
+	lines   []string
+	partial []byte
+	closed  bool
+}
+
+// New creates a log writing files into dir. Failing to write them is
+// reported to onError, the lines are kept in memory regardless.
+func New(dir string, config Config, onError func(error)) *Log {
+	return &Log{
+		dir:     dir,
+		config:  config,
+		onError: onError,
+	}
+}
+
+// Configure applies a new config from the next write on.
+func (l *Log) Configure(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.config = config
+	l.trim()
+}
+
+// Lines returns up to the last n lines, all kept lines when n is not
+// positive.
+func (l *Log) Lines(n int) []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 0 || n > l.config.Lines {
+		n = l.config.Lines
+	}
+
+	lines := l.lines
+	if n < len(lines) {
+		lines = lines[len(lines)-n:]
+	}
+
+	return append([]string(nil), lines...)
+}
+
+// Write appends output to the current file, starting a new one first when
+// there is none or it is full, and keeps its lines. It never fails, so the
+// server is never left blocked on a full pipe.
+func (l *Log) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.add(p)
+
+	if l.closed {
+		return len(p), nil
+	}
+
+	err := l.write(p)
+	if err != nil {
+		l.closeFile()
+		l.onError(fmt.Errorf("writing server log: %w", err))
+	}
+
+	return len(p), nil
+}
+
+func (l *Log) write(p []byte) error {
+	if l.file != nil && l.config.MaxSize > 0 && l.size >= l.config.MaxSize {
+		l.closeFile()
+	}
+
+	if l.file == nil {
+		err := l.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := l.file.Write(p)
+	l.size += int64(n)
+
+	return err
+}
+
+// Rotate ends the current file, so the next run of the server starts a new
+// one.
+func (l *Log) Rotate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.flush()
+	l.closeFile()
+}
+
+// Close ends the current file. Later output only goes to the kept lines.
+func (l *Log) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.closeFile()
+	l.closed = true
+}
+
+func (l *Log) open() error {
+	err := os.MkdirAll(l.dir, 0755)
+	if err != nil {
+		return err
+	}
+
+	for {
+		name := filePrefix + time.Now().Format(timeFormat) + fileSuffix
+
+		l.file, err = os.OpenFile(filepath.Join(l.dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+		if !errors.Is(err, fs.ErrExist) {
+			break
+		}
+	}
+	if err != nil {
+		l.file = nil
+		return err
+	}
+
+	l.size = 0
+
+	err = l.prune()
+	if err != nil {
+		l.onError(fmt.Errorf("removing old server logs: %w", err))
+	}
+
+	return nil
+}
+
+func (l *Log) closeFile() {
+	if l.file == nil {
+		return
+	}
+
+	err := l.file.Close()
+	if err != nil {
+		l.onError(fmt.Errorf("closing server log: %w", err))
+	}
+
+	l.file = nil
+}
+
+// prune removes the oldest files beyond MaxFiles.
+func (l *Log) prune() error {
+	files, err := filepath.Glob(filepath.Join(l.dir, filePrefix+"*"+fileSuffix))
+	if err != nil {
+		return err
+	}
+
+	// The timestamps sort in time order
+	sort.Strings(files)
+
+	for len(files) > max(l.config.MaxFiles, 1) {
+		err = os.Remove(files[0])
+		if err != nil {
+			return err
+		}
+		files = files[1:]
+	}
+
+	return nil
+}
+
+func (l *Log) add(data []byte) {
+	data = append(l.partial, data...)
+
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+
+		l.appendLine(string(bytes.TrimSuffix(data[:i], []byte("\r"))))
+		data = data[i+1:]
+	}
+
+	l.partial = append([]byte(nil), data...)
+}
+
+// flush keeps a last line that didn't end with a newline.
+func (l *Log) flush() {
+	if len(l.partial) > 0 {
+		l.appendLine(string(l.partial))
+		l.partial = nil
+	}
+}
+
+func (l *Log) appendLine(line string) {
+	l.lines = append(l.lines, line)
+
+	// Trimming only now and then keeps appending cheap
+	if len(l.lines) >= 2*l.config.Lines {
+		l.trim()
+	}
+}
+
+func (l *Log) trim() {
+	if len(l.lines) > l.config.Lines {
+		l.lines = append([]string(nil), l.lines[len(l.lines)-l.config.Lines:]...)
+	}
+}
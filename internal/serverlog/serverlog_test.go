@@ -0,0 +1,107 @@
+package serverlog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLog(t *testing.T, dir string, config Config) *Log {
+	t.Helper()
+
+	l := New(dir, config, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+	t.Cleanup(l.Close)
+
+	return l
+}
+
+func write(t *testing.T, l *Log, content string) {
+	t.Helper()
+
+	n, err := l.Write([]byte(content))
+	require.NoError(t, err)
+	require.Equal(t, len(content), n)
+}
+
+func logFiles(t *testing.T, dir string) []string {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, filePrefix+"*"+fileSuffix))
+	require.NoError(t, err)
+
+	return files
+}
+
+func TestLog_Lines(t *testing.T) {
+	l := newTestLog(t, t.TempDir(), Config{MaxFiles: 1, Lines: 3})
+
+	write(t, l, "one\ntwo\nthr")
+	assert.Equal(t, []string{"one", "two"}, l.Lines(0))
+
+	write(t, l, "ee\r\nfour\nfive\n")
+	assert.Equal(t, []string{"three", "four", "five"}, l.Lines(0))
+	assert.Equal(t, []string{"five"}, l.Lines(1))
+}
+
+func TestLog_NewFileAtMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, Config{MaxSize: 10, MaxFiles: 2, Lines: 100})
+
+	for i := 0; i < 3; i++ {
+		write(t, l, strings.Repeat("x", 9)+"\n")
+	}
+
+	files := logFiles(t, dir)
+	require.Len(t, files, 2)
+	assert.Len(t, l.Lines(0), 3)
+
+	// Full files are left as they are
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.Equal(t, strings.Repeat("x", 9)+"\n", string(content))
+	}
+}
+
+func TestLog_Rotate(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, Config{MaxFiles: 5, Lines: 100})
+
+	// No file until there is output
+	l.Rotate()
+	assert.Empty(t, logFiles(t, dir))
+
+	write(t, l, "first run")
+	l.Rotate()
+	write(t, l, "second run\n")
+
+	files := logFiles(t, dir)
+	require.Len(t, files, 2)
+
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first run", string(content))
+
+	content, err = os.ReadFile(files[1])
+	require.NoError(t, err)
+	assert.Equal(t, "second run\n", string(content))
+
+	assert.Equal(t, []string{"first run", "second run"}, l.Lines(0))
+}
+
+func TestLog_Close(t *testing.T) {
+	dir := t.TempDir()
+	l := newTestLog(t, dir, Config{MaxFiles: 5, Lines: 100})
+
+	l.Close()
+	write(t, l, "after close\n")
+
+	assert.Empty(t, logFiles(t, dir))
+	assert.Equal(t, []string{"after close"}, l.Lines(0))
+}
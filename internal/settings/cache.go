@@ -1,38 +1,44 @@
 package settings
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 
-	"github.com/goccy/go-yaml"
+	"github.com/sboon-gg/svctl/internal/persist"
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
 )
 
+// cacheMigrations upgrade cache files written by older versions, see
+// persist.ReadYAML.
+var cacheMigrations = []persist.Migration{
+	// 1 adds the version itself
+	func(doc map[string]any) error { return nil },
+}
+
 type Cache struct {
-	PID           int      `yaml:"pid"`
-	UpdatePatches []string `yaml:"update_patches"`
+	Version int `yaml:"version"`
+	PID     int `yaml:"pid"`
+	// Process identifies the process with PID, so a process reusing the PID
+	// is not mistaken for it
+	Process       *prbf2proc.Identity `yaml:"process,omitempty"`
+	UpdatePatches []string            `yaml:"update_patches"`
 }
 
 func NewCache() *Cache {
 	return &Cache{
-		PID: -1,
+		Version: len(cacheMigrations),
+		PID:     -1,
 	}
 }
 
 func (s *Settings) Cache() (*Cache, error) {
 	var cache Cache
 
-	cacheFile := filepath.Join(s.path, CacheFile)
-
-	if _, err := os.Stat(cacheFile); os.IsNotExist(err) {
+	err := persist.ReadYAML(s.cachePath(), &cache, cacheMigrations)
+	if errors.Is(err, os.ErrNotExist) {
 		return NewCache(), nil
 	}
-
-	content, err := os.ReadFile(cacheFile)
-	if err != nil {
-		return nil, err
-	}
-
-	err = yaml.Unmarshal(content, &cache)
 	if err != nil {
 		return nil, err
 	}
@@ -41,26 +47,37 @@ func (s *Settings) Cache() (*Cache, error) {
 }
 
 func (s *Settings) WriteCache(cache *Cache) error {
-	content, err := yaml.Marshal(cache)
-	if err != nil {
-		return err
-	}
+	return persist.WriteYAML(s.cachePath(), cache, 0644)
+}
 
-	return os.WriteFile(filepath.Join(s.path, CacheFile), content, 0644)
+// StoreProcess records the running process, pass -1 and nil once it is
+// gone.
+func (s *Settings) StoreProcess(pid int, identity *prbf2proc.Identity) error {
+	return s.updateCache(func(cache *Cache) {
+		cache.PID = pid
+		cache.Process = identity
+	})
 }
 
-func (s *Settings) StorePID(pid int) error {
-	cache, err := s.Cache()
+// updateCache applies fn to the cache and writes it back, holding the cache
+// lock so the daemon and the CLI don't overwrite each other.
+func (s *Settings) updateCache(fn func(*Cache)) error {
+	lock, err := persist.Lock(s.cachePath())
 	if err != nil {
 		return err
 	}
+	defer lock.Unlock()
 
-	cache.PID = pid
-
-	err = s.WriteCache(cache)
+	cache, err := s.Cache()
 	if err != nil {
 		return err
 	}
 
-	return nil
+	fn(cache)
+
+	return s.WriteCache(cache)
+}
+
+func (s *Settings) cachePath() string {
+	return filepath.Join(s.path, CacheFile)
 }
@@ -1,22 +1,304 @@
 package settings
 
 import (
+	"errors"
+	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/goccy/go-yaml"
+	"github.com/robfig/cron/v3"
+	"github.com/sboon-gg/svctl/internal/persist"
+	"github.com/sboon-gg/svctl/internal/serverlog"
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
 )
 
 type ValuesSource struct {
 	File string `yaml:"file"`
 }
 
+// StopConfig controls how the server process is stopped: Signal is sent
+// first and the process group is killed if it is still alive after Timeout.
+type StopConfig struct {
+	Signal  string        `yaml:"signal,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+type RestartPolicy string
+
+const (
+	RestartNever     RestartPolicy = "never"
+	RestartOnFailure RestartPolicy = "on-failure"
+	RestartAlways    RestartPolicy = "always"
+)
+
+// RestartConfig controls how an exited server is restarted. Restarts are
+// delayed by an exponential backoff starting at InitialDelay and capped at
+// MaxDelay. More than MaxRestarts restarts within Window park the server
+// in the Errored state.
+type RestartConfig struct {
+	Policy       RestartPolicy `yaml:"policy,omitempty"`
+	MaxRestarts  int           `yaml:"max_restarts,omitempty"`
+	Window       time.Duration `yaml:"window,omitempty"`
+	InitialDelay time.Duration `yaml:"initial_delay,omitempty"`
+	MaxDelay     time.Duration `yaml:"max_delay,omitempty"`
+}
+
+func DefaultRestartConfig() RestartConfig {
+	return RestartConfig{
+		Policy:       RestartAlways,
+		MaxRestarts:  5,
+		Window:       time.Minute,
+		InitialDelay: time.Second,
+		MaxDelay:     30 * time.Second,
+	}
+}
+
+// WithDefaults returns a copy of the config with unset fields defaulted.
+func (c *RestartConfig) WithDefaults() RestartConfig {
+	config := DefaultRestartConfig()
+	if c == nil {
+		return config
+	}
+
+	if c.Policy != "" {
+		config.Policy = c.Policy
+	}
+	if c.MaxRestarts > 0 {
+		config.MaxRestarts = c.MaxRestarts
+	}
+	if c.Window > 0 {
+		config.Window = c.Window
+	}
+	if c.InitialDelay > 0 {
+		config.InitialDelay = c.InitialDelay
+	}
+	if c.MaxDelay > 0 {
+		config.MaxDelay = c.MaxDelay
+	}
+
+	return config
+}
+
+func (c *RestartConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	switch c.Policy {
+	case "", RestartNever, RestartOnFailure, RestartAlways:
+	default:
+		return fmt.Errorf("invalid restart policy %q", c.Policy)
+	}
+
+	if c.InitialDelay > 0 && c.MaxDelay > 0 && c.MaxDelay < c.InitialDelay {
+		return errors.New("restart max_delay must not be lower than initial_delay")
+	}
+
+	return nil
+}
+
+// ReadyConfig decides when a started server is reported Running: its
+// process must stay up for Uptime and, when TCP is set, accept connections
+// on that address. A server not ready within Timeout is errored.
+type ReadyConfig struct {
+	Uptime  time.Duration `yaml:"uptime,omitempty"`
+	TCP     string        `yaml:"tcp,omitempty"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+}
+
+const defaultReadyTimeout = 2 * time.Minute
+
+// Enabled reports whether any readiness condition is set.
+func (c *ReadyConfig) Enabled() bool {
+	return c != nil && (c.Uptime > 0 || c.TCP != "")
+}
+
+// WithDefaults returns a copy of the config with unset fields defaulted.
+func (c *ReadyConfig) WithDefaults() ReadyConfig {
+	var config ReadyConfig
+	if c != nil {
+		config = *c
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = defaultReadyTimeout
+	}
+
+	return config
+}
+
+func (c *ReadyConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.TCP != "" {
+		_, _, err := net.SplitHostPort(c.TCP)
+		if err != nil {
+			return fmt.Errorf("invalid ready tcp address %q: %w", c.TCP, err)
+		}
+	}
+
+	if c.Timeout > 0 && c.Uptime >= c.Timeout {
+		return errors.New("ready uptime must be lower than timeout")
+	}
+
+	return nil
+}
+
+// OutputConfig controls the files in logs/ that the server output is
+// written to, rotated once they reach MaxSizeMB with MaxFiles of them kept,
+// and how many of the last Lines the daemon keeps in memory.
+type OutputConfig struct {
+	MaxSizeMB int `yaml:"max_size_mb,omitempty"`
+	MaxFiles  int `yaml:"max_files,omitempty"`
+	Lines     int `yaml:"lines,omitempty"`
+}
+
+const (
+	defaultOutputMaxSizeMB = 10
+	defaultOutputMaxFiles  = 5
+	defaultOutputLines     = 1000
+)
+
+// WithDefaults returns the config for the server log with unset fields
+// defaulted.
+func (c *OutputConfig) WithDefaults() serverlog.Config {
+	config := serverlog.Config{
+		MaxSize:  defaultOutputMaxSizeMB << 20,
+		MaxFiles: defaultOutputMaxFiles,
+		Lines:    defaultOutputLines,
+	}
+	if c == nil {
+		return config
+	}
+
+	if c.MaxSizeMB > 0 {
+		config.MaxSize = int64(c.MaxSizeMB) << 20
+	}
+	if c.MaxFiles > 0 {
+		config.MaxFiles = c.MaxFiles
+	}
+	if c.Lines > 0 {
+		config.Lines = c.Lines
+	}
+
+	return config
+}
+
+func (c *OutputConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.MaxSizeMB < 0 || c.MaxFiles < 0 || c.Lines < 0 {
+		return errors.New("output limits must not be negative")
+	}
+
+	return nil
+}
+
+type ScheduleAction string
+
+const (
+	ScheduleStart       ScheduleAction = "start"
+	ScheduleStop        ScheduleAction = "stop"
+	ScheduleRestart     ScheduleAction = "restart"
+	ScheduleRender      ScheduleAction = "render"
+	ScheduleUpdateCheck ScheduleAction = "update-check"
+)
+
+// ScheduleConfig runs Action whenever the standard 5-field cron expression
+// Cron matches. Descriptors like "@daily" and a "CRON_TZ=" prefix are
+// supported as well.
+type ScheduleConfig struct {
+	Name   string         `yaml:"name"`
+	Cron   string         `yaml:"cron"`
+	Action ScheduleAction `yaml:"action"`
+}
+
+func (c *ScheduleConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("schedule name must not be empty")
+	}
+
+	switch c.Action {
+	case ScheduleStart, ScheduleStop, ScheduleRestart, ScheduleRender, ScheduleUpdateCheck:
+	default:
+		return fmt.Errorf("schedule %q: invalid action %q", c.Name, c.Action)
+	}
+
+	_, err := cron.ParseStandard(c.Cron)
+	if err != nil {
+		return fmt.Errorf("schedule %q: invalid cron expression %q: %w", c.Name, c.Cron, err)
+	}
+
+	return nil
+}
+
 type Config struct {
-	Values  []ValuesSource `yaml:"values"`
-	Loggers []LoggerConfig `yaml:"loggers"`
+	Values    []ValuesSource   `yaml:"values"`
+	Loggers   []LoggerConfig   `yaml:"loggers"`
+	Stop      *StopConfig      `yaml:"stop,omitempty"`
+	Restart   *RestartConfig   `yaml:"restart,omitempty"`
+	Ready     *ReadyConfig     `yaml:"ready,omitempty"`
+	Launch    *LaunchConfig    `yaml:"launch,omitempty"`
+	Output    *OutputConfig    `yaml:"output,omitempty"`
+	Schedules []ScheduleConfig `yaml:"schedules,omitempty"`
 }
 
-func (s *Settings) Config() (*Config, error) {
+// Validate checks the parts of the config that would otherwise only be
+// reported, and replaced by defaults, once they are used.
+func (c *Config) Validate() error {
+	if c.Stop != nil && c.Stop.Signal != "" {
+		_, err := prbf2proc.ParseSignal(c.Stop.Signal)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := c.Restart.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Ready.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Launch.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = c.Output.Validate()
+	if err != nil {
+		return err
+	}
+
+	for _, sc := range c.Schedules {
+		err = sc.Validate()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Config returns the config as read by Open or the last successful Reload.
+func (s *Settings) Config() *Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.config
+}
+
+func (s *Settings) readConfig() (*Config, error) {
 	var config Config
 
 	content, err := os.ReadFile(filepath.Join(s.path, ConfigFile))
@@ -33,7 +315,16 @@ func (s *Settings) Config() (*Config, error) {
 }
 
 func (s *Settings) WriteConfig(conf *Config) error {
-	return writeConfig(s.path, conf)
+	err := writeConfig(s.path, conf)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.config = conf
+	s.mu.Unlock()
+
+	return nil
 }
 
 func writeConfig(path string, conf *Config) error {
@@ -42,5 +333,5 @@ func writeConfig(path string, conf *Config) error {
 		return err
 	}
 
-	return os.WriteFile(filepath.Join(path, ConfigFile), content, 0644)
+	return persist.WriteFile(filepath.Join(path, ConfigFile), content, 0644)
 }
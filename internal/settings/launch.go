@@ -0,0 +1,93 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sboon-gg/svctl/pkg/prbf2proc"
+	"github.com/sboon-gg/svctl/pkg/templates"
+)
+
+// LaunchConfig changes how the server is started. Args override or add
+// server arguments like "+port", Env adds environment variables and Wrapper
+// runs the server through a command like nice or taskset. Arg and Env
+// values are templates rendered with the values.
+type LaunchConfig struct {
+	Args    map[string]string `yaml:"args,omitempty"`
+	Env     map[string]string `yaml:"env,omitempty"`
+	Wrapper []string          `yaml:"wrapper,omitempty"`
+}
+
+func (c *LaunchConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for name := range c.Args {
+		if strings.TrimLeft(name, "+") == "" || strings.ContainsAny(name, " \t") {
+			return fmt.Errorf("invalid launch argument %q", name)
+		}
+	}
+
+	for name := range c.Env {
+		if name == "" || strings.Contains(name, "=") {
+			return fmt.Errorf("invalid launch environment variable %q", name)
+		}
+	}
+
+	if len(c.Wrapper) > 0 && c.Wrapper[0] == "" {
+		return errors.New("launch wrapper must start with a command")
+	}
+
+	return nil
+}
+
+// Launch renders the launch config with the current values.
+func (s *Settings) Launch() (prbf2proc.Launch, error) {
+	config := s.Config().Launch
+	if config == nil {
+		return prbf2proc.Launch{}, nil
+	}
+
+	err := config.Validate()
+	if err != nil {
+		return prbf2proc.Launch{}, err
+	}
+
+	launch := prbf2proc.Launch{
+		Args:    make(map[string]string, len(config.Args)),
+		Env:     make(map[string]string, len(config.Env)),
+		Wrapper: config.Wrapper,
+	}
+
+	if len(config.Args) == 0 && len(config.Env) == 0 {
+		return launch, nil
+	}
+
+	renderer := s.Templates()
+	if renderer == nil {
+		renderer = templates.New(&templates.Config{}, nil)
+	}
+
+	values, err := s.Values()
+	if err != nil {
+		return prbf2proc.Launch{}, err
+	}
+
+	for name, text := range config.Args {
+		launch.Args[name], err = renderer.RenderString("launch arg "+name, text, values)
+		if err != nil {
+			return prbf2proc.Launch{}, err
+		}
+	}
+
+	for name, text := range config.Env {
+		launch.Env[name], err = renderer.RenderString("launch env "+name, text, values)
+		if err != nil {
+			return prbf2proc.Launch{}, err
+		}
+	}
+
+	return launch, nil
+}
@@ -1,10 +1,13 @@
 package settings
 
 import (
+	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 
 	slogmulti "github.com/samber/slog-multi"
 	slogwebhook "github.com/samber/slog-webhook/v2"
@@ -38,8 +41,11 @@ type LoggerConfig struct {
 	Stdout  *StdoutLogger  `yaml:"std,omitempty"`
 }
 
-func NewLogger(settingsPath string, loggers []LoggerConfig) (*slog.Logger, error) {
+// newHandler builds a handler fanning records out to the configured
+// loggers. The returned files are to be closed once it is no longer used.
+func newHandler(settingsPath string, loggers []LoggerConfig) (slog.Handler, []io.Closer, error) {
 	handlers := make([]slog.Handler, len(loggers))
+	var files []io.Closer
 
 	for i, logger := range loggers {
 		switch {
@@ -64,8 +70,10 @@ func NewLogger(settingsPath string, loggers []LoggerConfig) (*slog.Logger, error
 
 			file, err := openOrCreateFile(path)
 			if err != nil {
-				return nil, err
+				closeAll(files)
+				return nil, nil, err
 			}
+			files = append(files, file)
 
 			options := &slog.HandlerOptions{
 				Level: logger.Level,
@@ -77,7 +85,8 @@ func NewLogger(settingsPath string, loggers []LoggerConfig) (*slog.Logger, error
 			case jsonLogger:
 				handlers[i] = slog.NewJSONHandler(file, options)
 			default:
-				return nil, errors.New("invalid logger type")
+				closeAll(files)
+				return nil, nil, errors.New("invalid logger type")
 			}
 		case logger.Stdout != nil:
 			options := &slog.HandlerOptions{
@@ -90,32 +99,97 @@ func NewLogger(settingsPath string, loggers []LoggerConfig) (*slog.Logger, error
 			case jsonLogger:
 				handlers[i] = slog.NewJSONHandler(os.Stdout, options)
 			default:
-				return nil, errors.New("invalid logger type")
+				closeAll(files)
+				return nil, nil, errors.New("invalid logger type")
 			}
 		}
 	}
 
-	return slog.New(slogmulti.Fanout(handlers...)), nil
+	return slogmulti.Fanout(handlers...), files, nil
 }
 
-func DiscordEmbedConverter(addSource bool, replaceAttr func(groups []string, a slog.Attr) slog.Attr, loggerAttr []slog.Attr, groups []string, record *slog.Record) map[string]any {
-	return nil
+func closeAll(files []io.Closer) {
+	for _, f := range files {
+		_ = f.Close()
+	}
 }
 
-func DiscordTextConverter(addSource bool, replaceAttr func(groups []string, a slog.Attr) slog.Attr, loggerAttr []slog.Attr, groups []string, record *slog.Record) map[string]any {
-	return nil
+// reloadableHandler passes records on to a handler that can be swapped, so
+// loggers derived with With and WithGroup follow reloads too.
+type reloadableHandler struct {
+	current *atomic.Pointer[slog.Handler]
+	// derive reapplies the attributes and groups added to this handler
+	derive func(slog.Handler) slog.Handler
+	// derived caches derive applied to the current handler until the next
+	// Swap, which stores a new pointer
+	derived atomic.Pointer[derivedHandler]
 }
 
-func openOrCreateFile(path string) (*os.File, error) {
-	_, err := os.Open(path)
+type derivedHandler struct {
+	base    *slog.Handler
+	handler slog.Handler
+}
+
+func newReloadableHandler(h slog.Handler) *reloadableHandler {
+	current := &atomic.Pointer[slog.Handler]{}
+	current.Store(&h)
+
+	return &reloadableHandler{
+		current: current,
+		derive:  func(h slog.Handler) slog.Handler { return h },
+	}
+}
+
+// Swap sends all records to h from now on.
+func (r *reloadableHandler) Swap(h slog.Handler) {
+	r.current.Store(&h)
+}
+
+func (r *reloadableHandler) handler() slog.Handler {
+	base := r.current.Load()
+
+	if d := r.derived.Load(); d != nil && d.base == base {
+		return d.handler
+	}
+
+	h := r.derive(*base)
+	r.derived.Store(&derivedHandler{base: base, handler: h})
 
-	if os.IsNotExist(err) {
-		return os.Create(path)
+	return h
+}
+
+func (r *reloadableHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return r.handler().Enabled(ctx, level)
+}
+
+func (r *reloadableHandler) Handle(ctx context.Context, record slog.Record) error {
+	return r.handler().Handle(ctx, record)
+}
+
+func (r *reloadableHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derive := r.derive
+	return &reloadableHandler{
+		current: r.current,
+		derive:  func(h slog.Handler) slog.Handler { return derive(h).WithAttrs(attrs) },
 	}
+}
 
-	if err != nil {
-		return nil, err
+func (r *reloadableHandler) WithGroup(name string) slog.Handler {
+	derive := r.derive
+	return &reloadableHandler{
+		current: r.current,
+		derive:  func(h slog.Handler) slog.Handler { return derive(h).WithGroup(name) },
 	}
+}
 
-	return os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+func DiscordEmbedConverter(addSource bool, replaceAttr func(groups []string, a slog.Attr) slog.Attr, loggerAttr []slog.Attr, groups []string, record *slog.Record) map[string]any {
+	return nil
+}
+
+func DiscordTextConverter(addSource bool, replaceAttr func(groups []string, a slog.Attr) slog.Attr, loggerAttr []slog.Attr, groups []string, record *slog.Record) map[string]any {
+	return nil
+}
+
+func openOrCreateFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 }
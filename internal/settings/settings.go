@@ -1,9 +1,14 @@
 package settings
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sync"
+	"time"
 
 	"github.com/sboon-gg/svctl/pkg/templates"
 )
@@ -11,6 +16,7 @@ import (
 const (
 	SvctlDir     = ".svctl"
 	TemplatesDir = "templates"
+	LogsDir      = "logs"
 
 	ConfigFile        = "config.yaml"
 	defaultValuesFile = "values.yaml"
@@ -19,9 +25,16 @@ const (
 )
 
 type Settings struct {
-	path      string
-	Templates *templates.Renderer
-	Log       *slog.Logger
+	path string
+	// Log sends records to the loggers of the current config, it keeps
+	// working across reloads
+	Log *slog.Logger
+
+	mu       sync.RWMutex
+	config   *Config
+	renderer *templates.Renderer
+	handler  *reloadableHandler
+	logFiles []io.Closer
 }
 
 func Open(path string) (*Settings, error) {
@@ -29,30 +42,110 @@ func Open(path string) (*Settings, error) {
 		path: path,
 	}
 
-	config, err := s.Config()
+	config, err := s.readConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	logger, err := NewLogger(path, config.Loggers)
+	handler, files, err := newHandler(path, config.Loggers)
 	if err != nil {
 		return nil, err
 	}
 
-	s.Log = logger
+	renderer, err := openTemplates(path)
+	if err != nil {
+		closeAll(files)
+		return nil, err
+	}
 
-	templatesPath := filepath.Join(path, TemplatesDir)
-	_, err = os.Stat(templatesPath)
+	s.config = config
+	s.renderer = renderer
+	s.logFiles = files
+	s.handler = newReloadableHandler(handler)
+	s.Log = slog.New(s.handler)
+
+	return s, nil
+}
+
+// ErrInvalid is returned by Reload when the new settings cannot be used.
+var ErrInvalid = errors.New("invalid settings")
+
+// Reload reads the config again, rebuilds the loggers and re-opens the
+// templates. check, when set, is called with the new settings before they
+// replace the current ones, to validate what is derived from them. When any
+// of that fails nothing changes and the settings read before stay in use.
+func (s *Settings) Reload(check func(*Settings) error) error {
+	config, err := s.readConfig()
 	if err == nil {
-		t, err := templates.NewFromPath(templatesPath)
+		err = config.Validate()
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	handler, files, err := newHandler(s.path, config.Loggers)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	renderer, err := openTemplates(s.path)
+	if err != nil {
+		closeAll(files)
+		return fmt.Errorf("%w: %w", ErrInvalid, err)
+	}
+
+	if check != nil {
+		// Logs to the current loggers until the new ones are in place
+		next := &Settings{
+			path:     s.path,
+			Log:      s.Log,
+			config:   config,
+			renderer: renderer,
+		}
+
+		err := check(next)
 		if err != nil {
-			return nil, err
+			closeAll(files)
+			return fmt.Errorf("%w: %w", ErrInvalid, err)
 		}
+	}
+
+	s.mu.Lock()
+	s.config = config
+	s.renderer = renderer
+	oldFiles := s.logFiles
+	s.logFiles = files
+	s.mu.Unlock()
+
+	s.handler.Swap(handler)
+	closeAll(oldFiles)
+
+	return nil
+}
+
+// Path returns the settings directory.
+func (s *Settings) Path() string {
+	return s.path
+}
 
-		s.Templates = t
+// Templates returns the template renderer, nil when the server has no
+// templates.
+func (s *Settings) Templates() *templates.Renderer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.renderer
+}
+
+func openTemplates(path string) (*templates.Renderer, error) {
+	templatesPath := filepath.Join(path, TemplatesDir)
+
+	_, err := os.Stat(templatesPath)
+	if err != nil {
+		return nil, nil
 	}
 
-	return s, nil
+	return templates.NewFromPath(templatesPath)
 }
 
 type Opts struct {
@@ -71,6 +164,17 @@ func Initialize(path string, opts *Opts) (*Settings, error) {
 	}
 
 	config := &Config{
+		Stop: &StopConfig{
+			Signal:  "SIGTERM",
+			Timeout: 30 * time.Second,
+		},
+		Restart: &RestartConfig{
+			Policy:       RestartAlways,
+			MaxRestarts:  5,
+			Window:       time.Minute,
+			InitialDelay: time.Second,
+			MaxDelay:     30 * time.Second,
+		},
 		Loggers: []LoggerConfig{
 			{
 				Level: slog.LevelDebug,
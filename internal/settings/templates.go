@@ -12,18 +12,14 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/goccy/go-yaml"
+	"github.com/sboon-gg/svctl/internal/persist"
 	"github.com/sboon-gg/svctl/pkg/templates"
 )
 
 func (s *Settings) Values() (templates.Values, error) {
 	var allValues templates.Values
 
-	config, err := s.Config()
-	if err != nil {
-		return nil, err
-	}
-
-	for _, source := range config.Values {
+	for _, source := range s.Config().Values {
 		if source.File != "" {
 			content, err := os.ReadFile(filepath.Join(s.path, source.File))
 			if err != nil {
@@ -66,7 +62,7 @@ func cloneTemplates(path, repoURL, token string) error {
 func writeValues(path string, content []byte) error {
 	commented := commentOutWholeYamlFile(string(content))
 
-	return os.WriteFile(filepath.Join(path, defaultValuesFile), []byte(commented), 0644)
+	return persist.WriteFile(filepath.Join(path, defaultValuesFile), []byte(commented), 0644)
 }
 
 func commentOutWholeYamlFile(content string) string {
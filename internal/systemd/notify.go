@@ -0,0 +1,76 @@
+// Package systemd integrates the daemon with systemd: readiness and status
+// notifications, the watchdog and unit files.
+package systemd
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+)
+
+// statusInterval is how often the status is refreshed without a watchdog.
+const statusInterval = 10 * time.Second
+
+// Notify reports readiness to systemd, then keeps its status line up to date
+// with status and sends watchdog keepalives until ctx is done. Keepalives are
+// only sent while alive succeeds within the keepalive interval, so systemd
+// restarts a daemon that stopped responding. It returns right away when not
+// running under systemd with Type=notify.
+func Notify(ctx context.Context, status func() string, alive func(context.Context) error) {
+	ok, err := daemon.SdNotify(false, daemon.SdNotifyReady+"\nSTATUS="+status())
+	if err != nil {
+		slog.Error("Failed to notify systemd", "error", err.Error())
+	}
+	if !ok {
+		return
+	}
+
+	interval := statusInterval
+
+	watchdog, err := daemon.SdWatchdogEnabled(false)
+	if err != nil {
+		slog.Error("Invalid systemd watchdog settings", "error", err.Error())
+	}
+
+	// Keepalives are sent twice per interval as systemd recommends
+	if watchdog > 0 && watchdog/2 < interval {
+		interval = watchdog / 2
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_, _ = daemon.SdNotify(false, daemon.SdNotifyStopping)
+			return
+		case <-ticker.C:
+		}
+
+		state := "STATUS=" + status()
+		if watchdog > 0 && checkAlive(ctx, alive, interval) {
+			state = daemon.SdNotifyWatchdog + "\n" + state
+		}
+
+		_, err := daemon.SdNotify(false, state)
+		if err != nil {
+			slog.Error("Failed to notify systemd", "error", err.Error())
+		}
+	}
+}
+
+func checkAlive(ctx context.Context, alive func(context.Context) error, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := alive(ctx)
+	if err != nil {
+		slog.Error("Skipping watchdog keepalive", "error", err.Error())
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+package systemd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/sboon-gg/svctl/internal/daemon"
+)
+
+const (
+	ServiceUnit = "svctl.service"
+	SocketUnit  = "svctl.socket"
+
+	systemUnitDir = "/etc/systemd/system"
+)
+
+// UnitOptions describes the units to generate.
+type UnitOptions struct {
+	// Exe is the absolute path of the svctl binary
+	Exe string
+	// Args are appended to "svctl daemon"
+	Args []string
+	// User generates units for the user service manager
+	User bool
+	// RunAs is the user running the daemon of a system unit
+	RunAs string
+	// Socket generates a socket unit listening on this path, so the daemon
+	// is socket activated
+	Socket string
+	// SocketGroup may use the socket of a system unit besides RunAs
+	SocketGroup string
+}
+
+// DefaultSocket returns the socket path matching the default listen address
+// of a daemon run by the service manager of the given scope: in
+// $XDG_RUNTIME_DIR for user services, daemon.SystemSocket for root.
+func DefaultSocket(user bool) string {
+	if user {
+		return "%t/svctl/svctl.sock"
+	}
+
+	return daemon.SystemSocket
+}
+
+// UnitDir returns where units of the given scope are installed.
+func UnitDir(user bool) (string, error) {
+	if !user {
+		return systemUnitDir, nil
+	}
+
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "systemd", "user"), nil
+}
+
+var serviceTemplate = template.Must(template.New(ServiceUnit).Parse(`[Unit]
+Description=svctl daemon supervising Project Reality servers
+{{- if not .User }}
+Wants=network-online.target
+After=network-online.target
+{{- end }}
+{{- if .Socket }}
+Requires=svctl.socket
+After=svctl.socket
+{{- end }}
+
+[Service]
+Type=notify
+NotifyAccess=main
+ExecStart={{ .ExecStart }}
+ExecReload=/bin/kill -HUP $MAINPID
+Restart=on-failure
+WatchdogSec=30
+# Servers keep running while the daemon restarts and are adopted again
+KillMode=process
+{{- if .RunAs }}
+User={{ .RunAs }}
+{{- end }}
+
+[Install]
+WantedBy={{ if .User }}default.target{{ else }}multi-user.target{{ end }}
+`))
+
+var socketTemplate = template.Must(template.New(SocketUnit).Parse(`[Unit]
+Description=svctl daemon socket
+
+[Socket]
+ListenStream={{ .Socket }}
+SocketMode=0660
+{{- if .RunAs }}
+SocketUser={{ .RunAs }}
+{{- end }}
+{{- if .SocketGroup }}
+SocketGroup={{ .SocketGroup }}
+{{- end }}
+
+[Install]
+WantedBy=sockets.target
+`))
+
+// Units renders the service unit and, when a socket is set, the socket
+// unit, keyed by file name.
+func Units(opts UnitOptions) (map[string][]byte, error) {
+	data := struct {
+		UnitOptions
+		ExecStart string
+	}{
+		UnitOptions: opts,
+		ExecStart:   execStart(opts.Exe, append([]string{"daemon"}, opts.Args...)),
+	}
+
+	units := map[string][]byte{}
+
+	var service bytes.Buffer
+	err := serviceTemplate.Execute(&service, data)
+	if err != nil {
+		return nil, err
+	}
+	units[ServiceUnit] = service.Bytes()
+
+	if opts.Socket != "" {
+		var socket bytes.Buffer
+		err := socketTemplate.Execute(&socket, data)
+		if err != nil {
+			return nil, err
+		}
+		units[SocketUnit] = socket.Bytes()
+	}
+
+	return units, nil
+}
+
+// execStart quotes the command line the way systemd parses ExecStart.
+func execStart(exe string, args []string) string {
+	words := make([]string, 0, len(args)+1)
+	for _, word := range append([]string{exe}, args...) {
+		words = append(words, quote(word))
+	}
+
+	return strings.Join(words, " ")
+}
+
+func quote(word string) string {
+	// Percent signs start specifiers in unit files
+	word = strings.ReplaceAll(word, "%", "%%")
+
+	if word != "" && !strings.ContainsAny(word, " \t\"'\\;$") {
+		return word
+	}
+
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "$", "$$")
+	return `"` + r.Replace(word) + `"`
+}
@@ -0,0 +1,58 @@
+package systemd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnits_System(t *testing.T) {
+	units, err := Units(UnitOptions{
+		Exe:         "/usr/local/bin/svctl",
+		Args:        []string{"--config", "/etc/svctl/my daemon.yaml"},
+		RunAs:       "prbf2",
+		Socket:      DefaultSocket(false),
+		SocketGroup: "prbf2-admins",
+	})
+	require.NoError(t, err)
+	require.Len(t, units, 2)
+
+	service := string(units[ServiceUnit])
+	assert.Contains(t, service, `ExecStart=/usr/local/bin/svctl daemon --config "/etc/svctl/my daemon.yaml"`)
+	assert.Contains(t, service, "Type=notify\n")
+	assert.Contains(t, service, "ExecReload=/bin/kill -HUP $MAINPID\n")
+	assert.Contains(t, service, "User=prbf2\n")
+	assert.Contains(t, service, "Requires=svctl.socket\n")
+	assert.Contains(t, service, "After=network-online.target\n")
+	assert.Contains(t, service, "WantedBy=multi-user.target\n")
+
+	socket := string(units[SocketUnit])
+	assert.Contains(t, socket, "ListenStream=/run/svctl/svctl.sock\n")
+	assert.Contains(t, socket, "SocketUser=prbf2\n")
+	assert.Contains(t, socket, "SocketGroup=prbf2-admins\n")
+}
+
+func TestUnits_User(t *testing.T) {
+	units, err := Units(UnitOptions{
+		Exe:  "/home/pr/bin/svctl",
+		User: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, units, 1)
+
+	service := string(units[ServiceUnit])
+	assert.Contains(t, service, "ExecStart=/home/pr/bin/svctl daemon\n")
+	assert.Contains(t, service, "WantedBy=default.target\n")
+	assert.NotContains(t, service, "network-online.target")
+	assert.NotContains(t, service, "User=")
+	assert.NotContains(t, service, "svctl.socket")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, "plain", quote("plain"))
+	assert.Equal(t, `"with space"`, quote("with space"))
+	assert.Equal(t, `"$$HOME"`, quote("$HOME"))
+	assert.Equal(t, "100%%", quote("100%"))
+	assert.Equal(t, `""`, quote(""))
+}
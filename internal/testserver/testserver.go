@@ -0,0 +1,37 @@
+// Package testserver creates PRBF2 server directories for tests.
+package testserver
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Exe is the path of the Linux server executable in a server directory.
+const Exe = "bin/amd-64/prbf2_l64ded"
+
+// New creates a server directory with script as the server executable and
+// files, keyed by their path in the directory, besides it.
+func New(t testing.TB, script string, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	all := map[string]string{
+		"mods/pr/mod.desc": "<mod><version>1.0.0.0</version></mod>",
+		Exe:                script,
+	}
+	for name, content := range files {
+		all[name] = content
+	}
+
+	for name, content := range all {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0755))
+	}
+
+	return dir
+}
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gocarina/gocsv"
@@ -13,19 +14,21 @@ import (
 var killer = newErrorKiller()
 
 type errorKiller struct {
+	mu               sync.Mutex
 	watchedProcesses []*PRBF2Process
-	ticker           *time.Ticker
 	cancel           context.CancelFunc
 }
 
 func newErrorKiller() *errorKiller {
 	return &errorKiller{
 		watchedProcesses: make([]*PRBF2Process, 0),
-		ticker:           &time.Ticker{},
 	}
 }
 
 func (ek *errorKiller) Watch(proc *PRBF2Process) {
+	ek.mu.Lock()
+	defer ek.mu.Unlock()
+
 	if len(ek.watchedProcesses) == 0 && runtime.GOOS == "windows" {
 		ek.start()
 	}
@@ -34,6 +37,9 @@ func (ek *errorKiller) Watch(proc *PRBF2Process) {
 }
 
 func (ek *errorKiller) Unwatch(proc *PRBF2Process) {
+	ek.mu.Lock()
+	defer ek.mu.Unlock()
+
 	for i, p := range ek.watchedProcesses {
 		if p == proc {
 			ek.watchedProcesses = append(ek.watchedProcesses[:i], ek.watchedProcesses[i+1:]...)
@@ -47,7 +53,7 @@ func (ek *errorKiller) Unwatch(proc *PRBF2Process) {
 }
 
 func (ek *errorKiller) start() {
-	ek.ticker.Reset(time.Millisecond * 500)
+	ticker := time.NewTicker(time.Millisecond * 500)
 	ctx, cancel := context.WithCancel(context.Background())
 	ek.cancel = cancel
 
@@ -55,9 +61,9 @@ func (ek *errorKiller) start() {
 		for {
 			select {
 			case <-ctx.Done():
-				ek.ticker.Stop()
+				ticker.Stop()
 				return
-			case <-ek.ticker.C:
+			case <-ticker.C:
 				ek.checkErrors()
 			}
 		}
@@ -67,6 +73,7 @@ func (ek *errorKiller) start() {
 func (ek *errorKiller) stop() {
 	if ek.cancel != nil {
 		ek.cancel()
+		ek.cancel = nil
 	}
 }
 
@@ -76,10 +83,16 @@ func (ek *errorKiller) checkErrors() {
 		return
 	}
 
-	for _, proc := range ek.watchedProcesses {
+	// Stopping a process unwatches it, so work on a copy
+	ek.mu.Lock()
+	watched := make([]*PRBF2Process, len(ek.watchedProcesses))
+	copy(watched, ek.watchedProcesses)
+	ek.mu.Unlock()
+
+	for _, proc := range watched {
 		pid := proc.Pid()
 		if _, ok := erroredPIDs[pid]; ok {
-			_ = proc.Stop()
+			_ = proc.Kill()
 		}
 	}
 }
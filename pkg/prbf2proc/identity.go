@@ -0,0 +1,91 @@
+package prbf2proc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/shirou/gopsutil/v3/process"
+)
+
+var ErrIdentityMismatch = errors.New("process identity does not match")
+
+// Identity tells a process apart from a later one reusing its PID.
+type Identity struct {
+	Exe string `yaml:"exe"`
+	// StartTime is the creation time in milliseconds since the epoch
+	StartTime int64    `yaml:"start_time"`
+	Cmdline   []string `yaml:"cmdline"`
+}
+
+// Equal reports whether i and o describe the same process.
+func (i *Identity) Equal(o *Identity) bool {
+	if i == nil || o == nil {
+		return i == o
+	}
+
+	return i.Exe == o.Exe && i.StartTime == o.StartTime && slices.Equal(i.Cmdline, o.Cmdline)
+}
+
+// ReadIdentity reads the identity of the process with the given PID.
+func ReadIdentity(pid int) (*Identity, error) {
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return nil, err
+	}
+
+	exe, err := proc.Exe()
+	if err != nil {
+		return nil, fmt.Errorf("reading executable of process %d: %w", pid, err)
+	}
+
+	startTime, err := proc.CreateTime()
+	if err != nil {
+		return nil, fmt.Errorf("reading start time of process %d: %w", pid, err)
+	}
+
+	cmdline, err := proc.CmdlineSlice()
+	if err != nil {
+		return nil, fmt.Errorf("reading command line of process %d: %w", pid, err)
+	}
+
+	return &Identity{
+		Exe:       exe,
+		StartTime: startTime,
+		Cmdline:   cmdline,
+	}, nil
+}
+
+// VerifyIdentity checks that the process with the given PID is the one
+// described by want. A process without a known identity never matches.
+func VerifyIdentity(pid int, want *Identity) error {
+	if want == nil {
+		return fmt.Errorf("%w: no identity recorded for process %d", ErrIdentityMismatch, pid)
+	}
+
+	got, err := ReadIdentity(pid)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrIdentityMismatch, err)
+	}
+
+	switch {
+	case got.Exe != want.Exe:
+		return fmt.Errorf("%w: process %d runs %s instead of %s", ErrIdentityMismatch, pid, got.Exe, want.Exe)
+	case got.StartTime != want.StartTime:
+		return fmt.Errorf("%w: process %d was started at a different time", ErrIdentityMismatch, pid)
+	case !slices.Equal(got.Cmdline, want.Cmdline):
+		return fmt.Errorf("%w: process %d has a different command line", ErrIdentityMismatch, pid)
+	}
+
+	return nil
+}
+
+// Identity returns the identity of the current process.
+func (p *PRBF2Process) Identity() (*Identity, error) {
+	pid := p.Pid()
+	if pid == -1 {
+		return nil, errors.New("no process running")
+	}
+
+	return ReadIdentity(pid)
+}
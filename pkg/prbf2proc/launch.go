@@ -0,0 +1,160 @@
+package prbf2proc
+
+import (
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// execTimeout bounds waiting for a wrapper command to exec the server.
+const execTimeout = time.Second
+
+// Launch customizes how the server is started.
+type Launch struct {
+	// Args override the default arguments of the same name, like "+port",
+	// and are appended otherwise. An empty value drops the argument.
+	Args map[string]string
+	// Env is added to the environment of the daemon
+	Env map[string]string
+	// Wrapper is a command like nice or taskset to run the server with. It
+	// has to exec the server for the process to be recognized when the
+	// daemon adopts it.
+	Wrapper []string
+}
+
+// WithLaunch sets the command line and environment of the server.
+func WithLaunch(launch Launch) Option {
+	return func(p *PRBF2Process) {
+		p.launch = launch
+	}
+}
+
+// Command returns the command line the server is started with and the
+// variables added to the environment of the daemon for it.
+func (p *PRBF2Process) Command() (argv []string, env []string) {
+	p.mu.Lock()
+	launch := p.launch
+	p.mu.Unlock()
+
+	return launch.command(p.path)
+}
+
+func (l Launch) command(path string) ([]string, []string) {
+	argv := append([]string{}, l.Wrapper...)
+	argv = append(argv, serverExe(path))
+	argv = append(argv, l.args()...)
+
+	env := serverEnv(path)
+
+	names := make([]string, 0, len(l.Env))
+	for name := range l.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		env = append(env, name+"="+l.Env[name])
+	}
+
+	return argv, mergeEnv(nil, env)
+}
+
+// args applies Args to the default arguments.
+func (l Launch) args() []string {
+	overrides := make(map[string]string, len(l.Args))
+	for name, value := range l.Args {
+		overrides[argName(name)] = value
+	}
+
+	var args []string
+
+	for i := 0; i+1 < len(defaultArgs); i += 2 {
+		name, value := defaultArgs[i], defaultArgs[i+1]
+
+		if override, ok := overrides[name]; ok {
+			value = override
+			delete(overrides, name)
+		}
+
+		if value != "" {
+			args = append(args, name, value)
+		}
+	}
+
+	names := make([]string, 0, len(overrides))
+	for name := range overrides {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if overrides[name] != "" {
+			args = append(args, name, overrides[name])
+		}
+	}
+
+	return args
+}
+
+func argName(name string) string {
+	if strings.HasPrefix(name, "+") {
+		return name
+	}
+
+	return "+" + name
+}
+
+// mergeEnv adds vars to env, replacing variables already set. Unlike
+// exec.Cmd, os.StartProcess passes duplicates on and the first one wins.
+func mergeEnv(env []string, vars []string) []string {
+	merged := make([]string, 0, len(env)+len(vars))
+	index := map[string]int{}
+
+	for _, kv := range append(env, vars...) {
+		name, _, _ := strings.Cut(kv, "=")
+
+		if i, ok := index[name]; ok {
+			merged[i] = kv
+			continue
+		}
+
+		index[name] = len(merged)
+		merged = append(merged, kv)
+	}
+
+	return merged
+}
+
+// lookWrapper returns the executable of the wrapper command, with symlinks
+// resolved like in the identity of its process.
+func lookWrapper(name string) (string, error) {
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", err
+	}
+
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.EvalSymlinks(path)
+}
+
+// awaitExec waits for the wrapper running as pid to exec the server, so the
+// identity recorded for the process is that of the server. Wrappers that
+// keep running are given up on after execTimeout.
+func awaitExec(pid int, wrapper string) {
+	deadline := time.Now().Add(execTimeout)
+
+	for time.Now().Before(deadline) {
+		identity, err := ReadIdentity(pid)
+		if err != nil || identity.Exe != wrapper {
+			return
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,44 @@
+package prbf2proc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLaunch_Args(t *testing.T) {
+	launch := Launch{
+		Args: map[string]string{
+			"+port":           "16567",
+			"config":          "serverconfig.con",
+			"+multi":          "2",
+			"noStatusMonitor": "",
+		},
+	}
+
+	assert.Equal(t, []string{
+		"+modPath", "mods/pr",
+		"+multi", "2",
+		"+dedicated", "1",
+		"+config", "serverconfig.con",
+		"+port", "16567",
+	}, launch.args())
+}
+
+func TestLaunch_Command(t *testing.T) {
+	launch := Launch{
+		Env:     map[string]string{"TZ": "UTC"},
+		Wrapper: []string{"nice", "-n", "5"},
+	}
+
+	argv, env := launch.command("/srv/pr")
+
+	assert.Equal(t, []string{"nice", "-n", "5", serverExe("/srv/pr")}, argv[:4])
+	assert.Equal(t, "TZ=UTC", env[len(env)-1])
+}
+
+func TestMergeEnv(t *testing.T) {
+	env := mergeEnv([]string{"PATH=/bin", "TZ=Europe/Berlin"}, []string{"TZ=UTC", "LANG=C"})
+
+	assert.Equal(t, []string{"PATH=/bin", "TZ=UTC", "LANG=C"}, env)
+}
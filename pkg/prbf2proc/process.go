@@ -1,45 +1,152 @@
 package prbf2proc
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/shirou/gopsutil/v3/process"
 )
 
+const (
+	DefaultStopTimeout = 30 * time.Second
+
+	// KillTimeout is how long to wait for the process to go away after SIGKILL
+	KillTimeout = 10 * time.Second
+)
+
+var DefaultStopSignal os.Signal = syscall.SIGTERM
+
+var (
+	ErrStopTimeout = errors.New("process did not exit after being killed")
+	// ErrUnknownExit is reported for adopted processes whose exit status
+	// cannot be collected.
+	ErrUnknownExit = errors.New("process exited with unknown status")
+)
+
+type Option func(*PRBF2Process)
+
+// WithStopSignal sets the signal sent to the server when stopping it.
+// A nil signal kills the server right away.
+func WithStopSignal(sig os.Signal) Option {
+	return func(p *PRBF2Process) {
+		p.stopSignal = sig
+	}
+}
+
+// WithOutput copies what the server prints to stdout and stderr to w
+// through a pipe. The output of adopted processes is not captured.
+func WithOutput(w io.Writer) Option {
+	return func(p *PRBF2Process) {
+		p.output = w
+	}
+}
+
+// WithStopTimeout sets how long the server gets to exit after the stop
+// signal before its whole process group is killed.
+func WithStopTimeout(timeout time.Duration) Option {
+	return func(p *PRBF2Process) {
+		p.stopTimeout = timeout
+	}
+}
+
 type PRBF2Process struct {
 	path string
 
+	stopSignal  os.Signal
+	stopTimeout time.Duration
+	launch      Launch
+	output      io.Writer
+
+	mu      sync.Mutex
 	process *os.Process
+	exit    *exitState
+}
+
+type exitState struct {
+	done chan struct{}
+	err  error
 }
 
-func New(path string) (*PRBF2Process, error) {
+func New(path string, opts ...Option) (*PRBF2Process, error) {
 	err := verifyPath(path)
 	if err != nil {
 		return nil, fmt.Errorf("Path %q is not a PRBF2 server", path)
 	}
 
-	return &PRBF2Process{
+	p := &PRBF2Process{
 		path: path,
-	}, nil
+	}
+
+	p.Configure(opts...)
+
+	return p, nil
 }
 
-func (p *PRBF2Process) Adopt(proc *os.Process) error {
-	p.process = proc
+// Configure resets the options to their defaults and applies opts. They
+// apply to a running server too.
+func (p *PRBF2Process) Configure(opts ...Option) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	if !p.IsRunning() {
-		p.process = nil
+	p.stopSignal = DefaultStopSignal
+	p.stopTimeout = DefaultStopTimeout
+	p.launch = Launch{}
+	p.output = nil
+
+	for _, opt := range opts {
+		opt(p)
+	}
+}
+
+// ParseSignal converts a signal name like "SIGTERM" or "TERM" to a signal.
+func ParseSignal(name string) (os.Signal, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	switch name {
+	case "SIGHUP":
+		return syscall.SIGHUP, nil
+	case "SIGINT":
+		return syscall.SIGINT, nil
+	case "SIGQUIT":
+		return syscall.SIGQUIT, nil
+	case "SIGTERM":
+		return syscall.SIGTERM, nil
+	case "SIGKILL":
+		return syscall.SIGKILL, nil
+	}
+
+	return nil, fmt.Errorf("unknown signal %q", name)
+}
+
+func (p *PRBF2Process) Adopt(proc *os.Process) error {
+	if !isRunning(proc.Pid) {
 		return fmt.Errorf("Process %d is not running", proc.Pid)
 	}
 
+	p.mu.Lock()
+	p.track(proc)
+	p.mu.Unlock()
+
 	killer.Watch(p)
 
 	return nil
 }
 
 func (p *PRBF2Process) Pid() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.process == nil {
 		return -1
 	}
@@ -48,73 +155,234 @@ func (p *PRBF2Process) Pid() int {
 }
 
 func (p *PRBF2Process) Start() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.process != nil {
 		return nil
 	}
 
-	proc, err := startProcess(p.path)
+	argv, env := p.launch.command(p.path)
+
+	name, wrapper := argv[0], ""
+	if len(p.launch.Wrapper) > 0 {
+		var err error
+		wrapper, err = lookWrapper(name)
+		if err != nil {
+			return err
+		}
+		name = wrapper
+	}
+
+	var r, w *os.File
+	if p.output != nil {
+		var err error
+		r, w, err = os.Pipe()
+		if err != nil {
+			return err
+		}
+		// The server has its own copy once started
+		defer w.Close()
+	}
+
+	proc, err := startProcess(p.path, name, argv, mergeEnv(os.Environ(), env), w)
 	if err != nil {
+		if r != nil {
+			r.Close()
+		}
 		return err
 	}
 
-	p.process = proc
+	if r != nil {
+		go copyOutput(p.output, r)
+	}
+
+	if wrapper != "" {
+		awaitExec(proc.Pid, wrapper)
+	}
+
+	p.track(proc)
 
 	killer.Watch(p)
 
 	return nil
 }
 
+// copyOutput copies the output of a server until it and every process that
+// inherited its stdout or stderr are gone.
+func copyOutput(w io.Writer, r *os.File) {
+	defer r.Close()
+
+	_, err := io.Copy(w, r)
+	if err != nil {
+		// Keeps the server from blocking on a full pipe
+		_, _ = io.Copy(io.Discard, r)
+	}
+}
+
+// Stop asks the server to exit with the stop signal and kills its process
+// group once the stop timeout passes. It returns after the process is gone.
 func (p *PRBF2Process) Stop() error {
-	if p.process == nil {
+	return p.stop(context.Background(), true)
+}
+
+// StopContext is like Stop, but gives up waiting for the server to exit
+// when ctx is done. The server is then left running with the stop signal
+// sent and ctx.Err() is returned.
+func (p *PRBF2Process) StopContext(ctx context.Context) error {
+	return p.stop(ctx, true)
+}
+
+// Kill kills the server process group without a grace period.
+func (p *PRBF2Process) Kill() error {
+	return p.stop(context.Background(), false)
+}
+
+func (p *PRBF2Process) stop(ctx context.Context, graceful bool) error {
+	p.mu.Lock()
+	proc, exit := p.process, p.exit
+	stopSignal, stopTimeout := p.stopSignal, p.stopTimeout
+	p.mu.Unlock()
+
+	if proc == nil {
 		return nil
 	}
 
+	exited := exit.done
+
 	killer.Unwatch(p)
 
-	if !p.IsRunning() {
-		p.process = nil
-		return nil
+	if graceful && stopSignal != nil && stopSignal != syscall.SIGKILL && stopTimeout > 0 {
+		err := signalGroup(proc, stopSignal)
+		if err == nil {
+			timer := time.NewTimer(stopTimeout)
+			defer timer.Stop()
+
+			select {
+			case <-exited:
+				p.release(proc)
+				return nil
+			case <-timer.C:
+			case <-ctx.Done():
+				killer.Watch(p)
+				return ctx.Err()
+			}
+		}
 	}
 
-	err := p.process.Kill()
-	if err != nil {
+	err := signalGroup(proc, syscall.SIGKILL)
+	if err != nil && !errors.Is(err, os.ErrProcessDone) {
 		return err
 	}
 
-	_ = p.process.Release()
+	select {
+	case <-exited:
+	case <-time.After(KillTimeout):
+		return ErrStopTimeout
+	}
 
-	p.process = nil
+	p.release(proc)
 
 	return nil
 }
 
+// release forgets proc unless another process was started in the meantime.
+func (p *PRBF2Process) release(proc *os.Process) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.process == proc {
+		p.process = nil
+	}
+}
+
 func (p *PRBF2Process) IsRunning() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	if p.process == nil {
 		return false
 	}
 
-	proc, err := process.NewProcess(int32(p.process.Pid))
-	if err != nil {
-		return false
+	return isRunning(p.process.Pid)
+}
+
+// Exited returns a channel that is closed once the current process exits.
+// It returns nil when no process is running.
+func (p *PRBF2Process) Exited() <-chan struct{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.process == nil {
+		return nil
 	}
 
-	isRunning, err := proc.IsRunning()
-	if err != nil {
-		return false
+	return p.exit.done
+}
+
+// ExitErr returns why the current process exited, nil for a clean exit.
+// It must only be called after the channel returned by Exited is closed.
+func (p *PRBF2Process) ExitErr() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.exit == nil {
+		return nil
 	}
 
-	return isRunning
+	return p.exit.err
 }
 
 func (p *PRBF2Process) Wait() {
-	_, _ = p.process.Wait()
+	exited := p.Exited()
+	if exited == nil {
+		return
+	}
+
+	<-exited
+}
 
-	for {
-		if !p.IsRunning() {
-			break
+// track must be called with p.mu held.
+func (p *PRBF2Process) track(proc *os.Process) {
+	p.process = proc
+	p.exit = &exitState{
+		done: make(chan struct{}),
+	}
+
+	go waitForExit(proc, p.exit)
+}
+
+func waitForExit(proc *os.Process, exit *exitState) {
+	defer close(exit.done)
+
+	state, err := proc.Wait()
+	if err == nil {
+		if !state.Success() {
+			exit.err = fmt.Errorf("process %s", state)
 		}
+		return
+	}
+
+	// Wait only works for child processes, adopted ones have to be polled
+	for isRunning(proc.Pid) {
 		time.Sleep(500 * time.Millisecond)
 	}
+
+	exit.err = ErrUnknownExit
+}
+
+func isRunning(pid int) bool {
+	proc, err := process.NewProcess(int32(pid))
+	if err != nil {
+		return false
+	}
+
+	isRunning, err := proc.IsRunning()
+	if err != nil {
+		return false
+	}
+
+	return isRunning
 }
 
 func verifyPath(path string) error {
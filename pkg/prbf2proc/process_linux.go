@@ -3,6 +3,7 @@
 package prbf2proc
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,27 +15,62 @@ const (
 	binaryDir = "bin/amd-64"
 )
 
-var args = []string{
+var defaultArgs = []string{
 	"+modPath", "mods/pr",
 	"+noStatusMonitor", "1",
 	"+multi", "1",
 	"+dedicated", "1",
 }
 
-func startProcess(path string) (*os.Process, error) {
-	binDir := filepath.Join(path, binaryDir)
-	fullExe := filepath.Join(binDir, exe)
+func serverExe(path string) string {
+	return filepath.Join(path, binaryDir, exe)
+}
+
+func serverEnv(path string) []string {
+	return []string{fmt.Sprintf("LD_LIBRARY_PATH=%s", filepath.Join(path, binaryDir))}
+}
 
-	env := os.Environ()
-	env = append(env, fmt.Sprintf("LD_LIBRARY_PATH=%s", binDir))
+func startProcess(path, name string, argv, env []string, output *os.File) (*os.Process, error) {
+	// Descriptors left closed would be taken by the next file the server
+	// opens, so it reads from /dev/null and writes there without output
+	devNull, err := os.OpenFile(os.DevNull, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer devNull.Close()
 
-	allArgs := append([]string{fullExe}, args...)
+	files := []*os.File{devNull, devNull, devNull}
+	if output != nil {
+		files[1], files[2] = output, output
+	}
 
-	return os.StartProcess(fullExe, allArgs, &os.ProcAttr{
-		Dir: path,
-		Env: env,
+	return os.StartProcess(name, argv, &os.ProcAttr{
+		Dir:   path,
+		Env:   env,
+		Files: files,
 		Sys: &syscall.SysProcAttr{
 			Setpgid: true,
 		},
 	})
 }
+
+// signalGroup signals the whole process group when proc leads one, which is
+// the case for servers started by svctl, and only proc otherwise.
+func signalGroup(proc *os.Process, sig os.Signal) error {
+	pgid, err := syscall.Getpgid(proc.Pid)
+	if err != nil || pgid != proc.Pid {
+		return proc.Signal(sig)
+	}
+
+	s, ok := sig.(syscall.Signal)
+	if !ok {
+		return proc.Signal(sig)
+	}
+
+	err = syscall.Kill(-pgid, s)
+	if errors.Is(err, syscall.ESRCH) {
+		return os.ErrProcessDone
+	}
+
+	return err
+}
@@ -0,0 +1,157 @@
+package prbf2proc
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sboon-gg/svctl/internal/testserver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProcess(t *testing.T, script string, opts ...Option) *PRBF2Process {
+	t.Helper()
+
+	p, err := New(testserver.New(t, script, nil), opts...)
+	require.NoError(t, err)
+
+	require.NoError(t, p.Start())
+	t.Cleanup(func() {
+		_ = p.Kill()
+	})
+
+	return p
+}
+
+func TestStop_Graceful(t *testing.T) {
+	p := newTestProcess(t, "#!/bin/sh\ntrap 'exit 0' TERM\nwhile :; do sleep 0.1; done\n",
+		WithStopTimeout(5*time.Second),
+	)
+
+	start := time.Now()
+	require.NoError(t, p.Stop())
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.False(t, p.IsRunning())
+	assert.Equal(t, -1, p.Pid())
+}
+
+func TestStop_KillsProcessGroupAfterTimeout(t *testing.T) {
+	p := newTestProcess(t, "#!/bin/sh\ntrap '' TERM\nsleep 1000 &\nwhile :; do sleep 0.1; done\n",
+		WithStopTimeout(200*time.Millisecond),
+	)
+
+	pgid := p.Pid()
+
+	// Give the script time to spawn its child
+	time.Sleep(100 * time.Millisecond)
+
+	require.NoError(t, p.Stop())
+
+	assert.False(t, p.IsRunning())
+	assert.Eventually(t, func() bool {
+		return !groupAlive(t, pgid)
+	}, time.Second, 20*time.Millisecond, "process group should be gone")
+}
+
+func TestStopContext_LeavesProcessRunning(t *testing.T) {
+	p := newTestProcess(t, "#!/bin/sh\ntrap '' TERM\nwhile :; do sleep 0.1; done\n",
+		WithStopTimeout(30*time.Second),
+	)
+
+	// Give the script time to ignore TERM
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	assert.ErrorIs(t, p.StopContext(ctx), context.DeadlineExceeded)
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.True(t, p.IsRunning())
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.buf.String()
+}
+
+func TestStart_Output(t *testing.T) {
+	var out syncBuffer
+	newTestProcess(t, "#!/bin/sh\necho out\necho err >&2\nreadlink /proc/$$/fd/0\nexec sleep 1000\n", WithOutput(&out))
+
+	assert.Eventually(t, func() bool {
+		return out.String() == "out\nerr\n"+os.DevNull+"\n"
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+// groupAlive reports whether any non-zombie process belongs to the group.
+// Orphaned zombies are ignored since they are reaped by init, if at all.
+func groupAlive(t *testing.T, pgid int) bool {
+	t.Helper()
+
+	stats, err := filepath.Glob("/proc/[0-9]*/stat")
+	require.NoError(t, err)
+
+	for _, stat := range stats {
+		content, err := os.ReadFile(stat)
+		if err != nil {
+			continue
+		}
+
+		// Fields after the command name: state, ppid, pgrp, ...
+		fields := strings.Fields(string(content[strings.LastIndexByte(string(content), ')')+1:]))
+		if len(fields) < 3 || fields[0] == "Z" {
+			continue
+		}
+
+		if fields[2] == strconv.Itoa(pgid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestVerifyIdentity(t *testing.T) {
+	p := newTestProcess(t, "#!/bin/sh\nsleep 1000\n")
+
+	identity, err := p.Identity()
+	require.NoError(t, err)
+	assert.NotEmpty(t, identity.Exe)
+	assert.NotZero(t, identity.StartTime)
+
+	assert.NoError(t, VerifyIdentity(p.Pid(), identity))
+
+	other := *identity
+	other.StartTime--
+	assert.ErrorIs(t, VerifyIdentity(p.Pid(), &other), ErrIdentityMismatch)
+
+	other = *identity
+	other.Cmdline = append([]string{}, identity.Cmdline[1:]...)
+	assert.ErrorIs(t, VerifyIdentity(p.Pid(), &other), ErrIdentityMismatch)
+
+	assert.ErrorIs(t, VerifyIdentity(p.Pid(), nil), ErrIdentityMismatch)
+}
@@ -3,7 +3,9 @@
 package prbf2proc
 
 import (
+	"errors"
 	"os"
+	"syscall"
 
 	"golang.org/x/sys/windows"
 )
@@ -12,23 +14,35 @@ const (
 	exe = "prbf2_w32ded.exe"
 )
 
-var args = []string{
+var defaultArgs = []string{
 	"+modPath", "mods/pr",
 	"+noStatusMonitor", "1",
 	"+multi", "1",
 	"+dedicated", "1",
 }
 
-func startProcess(path string) (*os.Process, error) {
-	allArgs := append([]string{exe}, args...)
+func serverExe(path string) string {
+	return exe
+}
+
+func serverEnv(path string) []string {
+	return nil
+}
+
+func startProcess(path, name string, argv, env []string, output *os.File) (*os.Process, error) {
+	files := []*os.File{
+		os.Stdin,
+		os.Stdout,
+		os.Stderr,
+	}
+	if output != nil {
+		files[1], files[2] = output, output
+	}
 
-	proc, err := os.StartProcess(exe, allArgs, &os.ProcAttr{
-		Dir: path,
-		Files: []*os.File{
-			os.Stdin,
-			os.Stdout,
-			os.Stderr,
-		},
+	proc, err := os.StartProcess(name, argv, &os.ProcAttr{
+		Dir:   path,
+		Env:   env,
+		Files: files,
 	})
 	if err != nil {
 		return nil, err
@@ -54,3 +68,13 @@ func setHighPriority(pid int) error {
 
 	return windows.CloseHandle(handle)
 }
+
+// signalGroup only supports killing on Windows, other signals fail so that
+// Stop escalates to a kill right away.
+func signalGroup(proc *os.Process, sig os.Signal) error {
+	if sig != syscall.SIGKILL {
+		return errors.ErrUnsupported
+	}
+
+	return proc.Kill()
+}
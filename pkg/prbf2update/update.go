@@ -89,6 +89,10 @@ func (u *PRBF2Update) FetchPatches() error {
 	return u.cache.FetchFor(current, latest)
 }
 
+func (u *PRBF2Update) CurrentVersion() (string, error) {
+	return u.currentVersion()
+}
+
 func (u *PRBF2Update) versions() (current, latest string, err error) {
 	current, err = u.currentVersion()
 	if err != nil {
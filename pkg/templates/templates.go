@@ -174,6 +174,22 @@ func (t *Renderer) Render(values Values) ([]RenderOutput, error) {
 	return rendered, nil
 }
 
+// RenderString renders a single template with the same values and functions
+// as the template files.
+func (t *Renderer) RenderString(name, text string, values Values) (string, error) {
+	data, err := t.prepData(values)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := t.render(name, text, data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (t *Renderer) RenderInto(path string, values Values) error {
 	outputs, err := t.Render(values)
 	if err != nil {
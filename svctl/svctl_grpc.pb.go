@@ -22,9 +22,21 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ServersClient interface {
-	Start(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error)
-	Stop(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	Start(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	Stop(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	Restart(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	Reset(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error)
 	Register(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	Unregister(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error)
+	GetServer(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerStatus, error)
+	ListServers(ctx context.Context, in *ListServersOpts, opts ...grpc.CallOption) (*ServerList, error)
+	WatchEvents(ctx context.Context, in *WatchEventsOpts, opts ...grpc.CallOption) (Servers_WatchEventsClient, error)
+	ListSchedules(ctx context.Context, in *ListSchedulesOpts, opts ...grpc.CallOption) (*ScheduleList, error)
+	SkipSchedule(ctx context.Context, in *SkipScheduleOpts, opts ...grpc.CallOption) (*Schedule, error)
+	Audit(ctx context.Context, in *AuditOpts, opts ...grpc.CallOption) (Servers_AuditClient, error)
+	SetAutostart(ctx context.Context, in *AutostartOpts, opts ...grpc.CallOption) (*ServerStatus, error)
+	Reload(ctx context.Context, in *ReloadOpts, opts ...grpc.CallOption) (*ReloadResult, error)
+	GetOutput(ctx context.Context, in *OutputOpts, opts ...grpc.CallOption) (*Output, error)
 }
 
 type serversClient struct {
@@ -35,7 +47,7 @@ func NewServersClient(cc grpc.ClientConnInterface) ServersClient {
 	return &serversClient{cc}
 }
 
-func (c *serversClient) Start(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
+func (c *serversClient) Start(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
 	out := new(ServerInfo)
 	err := c.cc.Invoke(ctx, "/svctl.Servers/Start", in, out, opts...)
 	if err != nil {
@@ -44,7 +56,7 @@ func (c *serversClient) Start(ctx context.Context, in *ServerOpts, opts ...grpc.
 	return out, nil
 }
 
-func (c *serversClient) Stop(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
+func (c *serversClient) Stop(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
 	out := new(ServerInfo)
 	err := c.cc.Invoke(ctx, "/svctl.Servers/Stop", in, out, opts...)
 	if err != nil {
@@ -53,6 +65,24 @@ func (c *serversClient) Stop(ctx context.Context, in *ServerOpts, opts ...grpc.C
 	return out, nil
 }
 
+func (c *serversClient) Restart(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/Restart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) Reset(ctx context.Context, in *ActionOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/Reset", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serversClient) Register(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
 	out := new(ServerInfo)
 	err := c.cc.Invoke(ctx, "/svctl.Servers/Register", in, out, opts...)
@@ -62,13 +92,161 @@ func (c *serversClient) Register(ctx context.Context, in *ServerOpts, opts ...gr
 	return out, nil
 }
 
+func (c *serversClient) Unregister(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerInfo, error) {
+	out := new(ServerInfo)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/Unregister", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) GetServer(ctx context.Context, in *ServerOpts, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/GetServer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) ListServers(ctx context.Context, in *ListServersOpts, opts ...grpc.CallOption) (*ServerList, error) {
+	out := new(ServerList)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/ListServers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) WatchEvents(ctx context.Context, in *WatchEventsOpts, opts ...grpc.CallOption) (Servers_WatchEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Servers_ServiceDesc.Streams[0], "/svctl.Servers/WatchEvents", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serversWatchEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Servers_WatchEventsClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type serversWatchEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *serversWatchEventsClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serversClient) ListSchedules(ctx context.Context, in *ListSchedulesOpts, opts ...grpc.CallOption) (*ScheduleList, error) {
+	out := new(ScheduleList)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/ListSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) SkipSchedule(ctx context.Context, in *SkipScheduleOpts, opts ...grpc.CallOption) (*Schedule, error) {
+	out := new(Schedule)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/SkipSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) Audit(ctx context.Context, in *AuditOpts, opts ...grpc.CallOption) (Servers_AuditClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Servers_ServiceDesc.Streams[1], "/svctl.Servers/Audit", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &serversAuditClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Servers_AuditClient interface {
+	Recv() (*AuditEntry, error)
+	grpc.ClientStream
+}
+
+type serversAuditClient struct {
+	grpc.ClientStream
+}
+
+func (x *serversAuditClient) Recv() (*AuditEntry, error) {
+	m := new(AuditEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *serversClient) SetAutostart(ctx context.Context, in *AutostartOpts, opts ...grpc.CallOption) (*ServerStatus, error) {
+	out := new(ServerStatus)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/SetAutostart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) Reload(ctx context.Context, in *ReloadOpts, opts ...grpc.CallOption) (*ReloadResult, error) {
+	out := new(ReloadResult)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/Reload", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serversClient) GetOutput(ctx context.Context, in *OutputOpts, opts ...grpc.CallOption) (*Output, error) {
+	out := new(Output)
+	err := c.cc.Invoke(ctx, "/svctl.Servers/GetOutput", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServersServer is the server API for Servers service.
 // All implementations must embed UnimplementedServersServer
 // for forward compatibility
 type ServersServer interface {
-	Start(context.Context, *ServerOpts) (*ServerInfo, error)
-	Stop(context.Context, *ServerOpts) (*ServerInfo, error)
+	Start(context.Context, *ActionOpts) (*ServerInfo, error)
+	Stop(context.Context, *ActionOpts) (*ServerInfo, error)
+	Restart(context.Context, *ActionOpts) (*ServerInfo, error)
+	Reset(context.Context, *ActionOpts) (*ServerInfo, error)
 	Register(context.Context, *ServerOpts) (*ServerInfo, error)
+	Unregister(context.Context, *ServerOpts) (*ServerInfo, error)
+	GetServer(context.Context, *ServerOpts) (*ServerStatus, error)
+	ListServers(context.Context, *ListServersOpts) (*ServerList, error)
+	WatchEvents(*WatchEventsOpts, Servers_WatchEventsServer) error
+	ListSchedules(context.Context, *ListSchedulesOpts) (*ScheduleList, error)
+	SkipSchedule(context.Context, *SkipScheduleOpts) (*Schedule, error)
+	Audit(*AuditOpts, Servers_AuditServer) error
+	SetAutostart(context.Context, *AutostartOpts) (*ServerStatus, error)
+	Reload(context.Context, *ReloadOpts) (*ReloadResult, error)
+	GetOutput(context.Context, *OutputOpts) (*Output, error)
 	mustEmbedUnimplementedServersServer()
 }
 
@@ -76,15 +254,51 @@ type ServersServer interface {
 type UnimplementedServersServer struct {
 }
 
-func (UnimplementedServersServer) Start(context.Context, *ServerOpts) (*ServerInfo, error) {
+func (UnimplementedServersServer) Start(context.Context, *ActionOpts) (*ServerInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
-func (UnimplementedServersServer) Stop(context.Context, *ServerOpts) (*ServerInfo, error) {
+func (UnimplementedServersServer) Stop(context.Context, *ActionOpts) (*ServerInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Stop not implemented")
 }
+func (UnimplementedServersServer) Restart(context.Context, *ActionOpts) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restart not implemented")
+}
+func (UnimplementedServersServer) Reset(context.Context, *ActionOpts) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
+}
 func (UnimplementedServersServer) Register(context.Context, *ServerOpts) (*ServerInfo, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
+func (UnimplementedServersServer) Unregister(context.Context, *ServerOpts) (*ServerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Unregister not implemented")
+}
+func (UnimplementedServersServer) GetServer(context.Context, *ServerOpts) (*ServerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServer not implemented")
+}
+func (UnimplementedServersServer) ListServers(context.Context, *ListServersOpts) (*ServerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListServers not implemented")
+}
+func (UnimplementedServersServer) WatchEvents(*WatchEventsOpts, Servers_WatchEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchEvents not implemented")
+}
+func (UnimplementedServersServer) ListSchedules(context.Context, *ListSchedulesOpts) (*ScheduleList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchedules not implemented")
+}
+func (UnimplementedServersServer) SkipSchedule(context.Context, *SkipScheduleOpts) (*Schedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SkipSchedule not implemented")
+}
+func (UnimplementedServersServer) Audit(*AuditOpts, Servers_AuditServer) error {
+	return status.Errorf(codes.Unimplemented, "method Audit not implemented")
+}
+func (UnimplementedServersServer) SetAutostart(context.Context, *AutostartOpts) (*ServerStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutostart not implemented")
+}
+func (UnimplementedServersServer) Reload(context.Context, *ReloadOpts) (*ReloadResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reload not implemented")
+}
+func (UnimplementedServersServer) GetOutput(context.Context, *OutputOpts) (*Output, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutput not implemented")
+}
 func (UnimplementedServersServer) mustEmbedUnimplementedServersServer() {}
 
 // UnsafeServersServer may be embedded to opt out of forward compatibility for this service.
@@ -99,7 +313,7 @@ func RegisterServersServer(s grpc.ServiceRegistrar, srv ServersServer) {
 }
 
 func _Servers_Start_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServerOpts)
+	in := new(ActionOpts)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -111,13 +325,13 @@ func _Servers_Start_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: "/svctl.Servers/Start",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServersServer).Start(ctx, req.(*ServerOpts))
+		return srv.(ServersServer).Start(ctx, req.(*ActionOpts))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _Servers_Stop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServerOpts)
+	in := new(ActionOpts)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -129,7 +343,43 @@ func _Servers_Stop_Handler(srv interface{}, ctx context.Context, dec func(interf
 		FullMethod: "/svctl.Servers/Stop",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ServersServer).Stop(ctx, req.(*ServerOpts))
+		return srv.(ServersServer).Stop(ctx, req.(*ActionOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_Restart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).Restart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/Restart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).Restart(ctx, req.(*ActionOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_Reset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActionOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).Reset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/Reset",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).Reset(ctx, req.(*ActionOpts))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -152,6 +402,192 @@ func _Servers_Register_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Servers_Unregister_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).Unregister(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/Unregister",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).Unregister(ctx, req.(*ServerOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_GetServer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).GetServer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/GetServer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).GetServer(ctx, req.(*ServerOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_ListServers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListServersOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).ListServers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/ListServers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).ListServers(ctx, req.(*ListServersOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_WatchEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEventsOpts)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServersServer).WatchEvents(m, &serversWatchEventsServer{stream})
+}
+
+type Servers_WatchEventsServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type serversWatchEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *serversWatchEventsServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Servers_ListSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchedulesOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).ListSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/ListSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).ListSchedules(ctx, req.(*ListSchedulesOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_SkipSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SkipScheduleOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).SkipSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/SkipSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).SkipSchedule(ctx, req.(*SkipScheduleOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_Audit_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AuditOpts)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServersServer).Audit(m, &serversAuditServer{stream})
+}
+
+type Servers_AuditServer interface {
+	Send(*AuditEntry) error
+	grpc.ServerStream
+}
+
+type serversAuditServer struct {
+	grpc.ServerStream
+}
+
+func (x *serversAuditServer) Send(m *AuditEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Servers_SetAutostart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutostartOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).SetAutostart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/SetAutostart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).SetAutostart(ctx, req.(*AutostartOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_Reload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).Reload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/Reload",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).Reload(ctx, req.(*ReloadOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Servers_GetOutput_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OutputOpts)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServersServer).GetOutput(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/svctl.Servers/GetOutput",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServersServer).GetOutput(ctx, req.(*OutputOpts))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Servers_ServiceDesc is the grpc.ServiceDesc for Servers service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -167,11 +603,62 @@ var Servers_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Stop",
 			Handler:    _Servers_Stop_Handler,
 		},
+		{
+			MethodName: "Restart",
+			Handler:    _Servers_Restart_Handler,
+		},
+		{
+			MethodName: "Reset",
+			Handler:    _Servers_Reset_Handler,
+		},
 		{
 			MethodName: "Register",
 			Handler:    _Servers_Register_Handler,
 		},
+		{
+			MethodName: "Unregister",
+			Handler:    _Servers_Unregister_Handler,
+		},
+		{
+			MethodName: "GetServer",
+			Handler:    _Servers_GetServer_Handler,
+		},
+		{
+			MethodName: "ListServers",
+			Handler:    _Servers_ListServers_Handler,
+		},
+		{
+			MethodName: "ListSchedules",
+			Handler:    _Servers_ListSchedules_Handler,
+		},
+		{
+			MethodName: "SkipSchedule",
+			Handler:    _Servers_SkipSchedule_Handler,
+		},
+		{
+			MethodName: "SetAutostart",
+			Handler:    _Servers_SetAutostart_Handler,
+		},
+		{
+			MethodName: "Reload",
+			Handler:    _Servers_Reload_Handler,
+		},
+		{
+			MethodName: "GetOutput",
+			Handler:    _Servers_GetOutput_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEvents",
+			Handler:       _Servers_WatchEvents_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Audit",
+			Handler:       _Servers_Audit_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "svctl/svctl.proto",
 }